@@ -0,0 +1,154 @@
+package source
+
+import "fmt"
+
+// ----------------- Actions du joueur -----------------
+// Les touches ne sont jamais lues directement par le jeu : elles sont
+// traduites en actions nommées via une table de correspondance (Bindings).
+
+// Action est une commande du joueur indépendante du périphérique
+type Action int
+
+const (
+	ActionMoveUp Action = iota
+	ActionMoveDown
+	ActionMoveLeft
+	ActionMoveRight
+	ActionAttack
+	ActionUseSword
+	ActionCombatItems
+	ActionSkill1
+	ActionSkill2
+	ActionSkill3
+	ActionSkill4
+	ActionFlee
+	ActionToggleInventory
+	ActionZoomIn
+	ActionZoomOut
+	ActionCameraUp
+	ActionCameraDown
+	ActionCameraLeft
+	ActionCameraRight
+	ActionClick
+	ActionPause
+	ActionNavUp
+	ActionNavDown
+	ActionNavLeft
+	ActionNavRight
+	ActionConfirm
+
+	actionCount // Nombre d'actions (à garder en dernier)
+)
+
+// Noms des actions utilisés dans le fichier de configuration
+var actionNames = [actionCount]string{
+	ActionMoveUp:          "MoveUp",
+	ActionMoveDown:        "MoveDown",
+	ActionMoveLeft:        "MoveLeft",
+	ActionMoveRight:       "MoveRight",
+	ActionAttack:          "Attack",
+	ActionUseSword:        "UseSword",
+	ActionCombatItems:     "CombatItems",
+	ActionSkill1:          "Skill1",
+	ActionSkill2:          "Skill2",
+	ActionSkill3:          "Skill3",
+	ActionSkill4:          "Skill4",
+	ActionFlee:            "Flee",
+	ActionToggleInventory: "ToggleInventory",
+	ActionZoomIn:          "ZoomIn",
+	ActionZoomOut:         "ZoomOut",
+	ActionCameraUp:        "CameraUp",
+	ActionCameraDown:      "CameraDown",
+	ActionCameraLeft:      "CameraLeft",
+	ActionCameraRight:     "CameraRight",
+	ActionClick:           "Click",
+	ActionPause:           "Pause",
+	ActionNavUp:           "NavUp",
+	ActionNavDown:         "NavDown",
+	ActionNavLeft:         "NavLeft",
+	ActionNavRight:        "NavRight",
+	ActionConfirm:         "Confirm",
+}
+
+// String retourne le nom de l'action
+func (a Action) String() string {
+	if a < 0 || a >= actionCount {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// Anciens noms d'actions encore acceptés dans le fichier de configuration
+var actionAliases = map[string]Action{
+	"DrinkShieldPotion": ActionCombatItems, // Les potions passent par le menu des objets
+	"DrinkHealPotion":   ActionCombatItems,
+}
+
+// ParseAction retrouve une action à partir de son nom
+func ParseAction(name string) (Action, error) {
+	for a, n := range actionNames {
+		if n == name {
+			return Action(a), nil
+		}
+	}
+	if a, ok := actionAliases[name]; ok {
+		return a, nil
+	}
+	return 0, fmt.Errorf("action inconnue : %q", name)
+}
+
+// ActionState mémorise l'état des actions sur deux ticks pour détecter
+// les appuis (JustPressed) et les relâchements (JustReleased)
+type ActionState struct {
+	held [actionCount]bool // Actions actives ce tick
+	prev [actionCount]bool // Actions actives au tick précédent
+}
+
+// Update lit l'état de chaque action pour le nouveau tick
+func (s *ActionState) Update(pressed func(Action) bool) {
+	s.prev = s.held
+	for a := Action(0); a < actionCount; a++ {
+		s.held[a] = pressed(a)
+	}
+}
+
+// Held indique si l'action est maintenue
+func (s *ActionState) Held(a Action) bool {
+	return s.held[a]
+}
+
+// JustPressed indique si l'action vient d'être déclenchée ce tick
+func (s *ActionState) JustPressed(a Action) bool {
+	return s.held[a] && !s.prev[a]
+}
+
+// JustReleased indique si l'action vient d'être relâchée ce tick
+func (s *ActionState) JustReleased(a Action) bool {
+	return !s.held[a] && s.prev[a]
+}
+
+// skillActions associe les touches de compétence à leur rang
+var skillActions = []Action{ActionSkill1, ActionSkill2, ActionSkill3, ActionSkill4}
+
+// Input construit l'instantané d'entrées de la simulation à partir des actions
+func (s *ActionState) Input() Input {
+	in := Input{
+		Up:    s.Held(ActionMoveUp),
+		Down:  s.Held(ActionMoveDown),
+		Left:  s.Held(ActionMoveLeft),
+		Right: s.Held(ActionMoveRight),
+
+		Punch:       s.JustPressed(ActionAttack),
+		Sword:       s.JustPressed(ActionUseSword),
+		CombatItems: s.JustPressed(ActionCombatItems),
+		Flee:        s.JustPressed(ActionFlee),
+
+		ToggleInventory: s.JustPressed(ActionToggleInventory),
+	}
+	for i, a := range skillActions {
+		if s.JustPressed(a) {
+			in.UseSkill, in.SkillSlot = true, i
+		}
+	}
+	return in
+}
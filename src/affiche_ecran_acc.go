@@ -2,9 +2,10 @@ package source
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
-	"image/color"
 	"log"
+	"math/rand/v2"
 	"os"
 	"time"
 
@@ -15,22 +16,25 @@ import (
 )
 
 var (
-	audioCtx     *audio.Context
-	player       *audio.Player
-	gameInstance *Game
+	audioCtx *audio.Context
+	player   *audio.Player
 )
 
-// Game représente l'état du jeu
+// Game adapte la simulation (World) à Ebiten : il lit le clavier et la souris,
+// fait avancer le World d'un tick et dessine son état
 type Game struct {
-	frames        []*ebiten.Image
-	index         int
-	inMenu        bool // true = menu, false = jeu
-	lastFrameTime time.Time
-	frameDelay    time.Duration
-	videoEnded    bool
-	inventaire    *InventaireGUI
-	player        *Personnage
-	marchand      *MenuMarchand
+	frames     []*ebiten.Image // Frames de la vidéo d'introduction
+	frameDelay time.Duration
+	scenes     SceneManager // Pile des écrans (titre, exploration, combat...)
+	world      *World
+	inventaire *InventaireGUI
+	marchand   *MenuMarchand
+	screenW    int // Taille de l'écran (dernier Layout)
+	screenH    int
+	controls   *Bindings   // Touches associées aux actions
+	gamepads   *Gamepads   // Manettes branchées
+	actions    ActionState // État des actions du tick courant
+	seed       uint64      // Graine imposée par -seed (0 = graine aléatoire)
 
 	camera Camera
 }
@@ -41,28 +45,20 @@ type Camera struct {
 	Zoom float64 // Facteur de zoom
 }
 
-// NewGame charge les frames de la vidéo
-func NewGame() *Game {
-	player := &Personnage{
-		Name:      "Héros",
-		Life:      100,
-		MaxLife:   100,
-		Shield:    0,
-		MaxShield: 100, // valeur de base
-		Strength:  10,
-		Money:     100,
-		Inventory: []string{},
+// NewGame charge les frames de la vidéo. seed impose la graine des
+// nouvelles parties (0 = graine aléatoire).
+func NewGame(seed uint64) *Game {
+	controls, err := LoadBindings()
+	if err != nil {
+		log.Println("Contrôles par défaut utilisés :", err)
 	}
 
 	g := &Game{
 		frameDelay: time.Millisecond * 42,
-		inMenu:     true,
 
-		player: player,
-		inventaire: &InventaireGUI{
-			player: player,
-		},
-		marchand: NewMenuMarchand(player),
+		controls: controls,
+		gamepads: NewGamepads(controls),
+		seed:     seed,
 		camera: Camera{
 			X:    0,
 			Y:    0,
@@ -82,11 +78,52 @@ func NewGame() *Game {
 		g.frames = append(g.frames, img)
 	}
 
-	g.lastFrameTime = time.Now()
+	g.newWorld()
+	g.scenes.Push(g, &TitleScene{})
 
 	return g
 }
 
+// newWorld démarre une nouvelle partie
+func (g *Game) newWorld() {
+	if g.seed != 0 {
+		g.world = NewWorldSeed(g.seed)
+	} else {
+		g.world = NewWorld()
+	}
+	g.inventaire = NewInventaireGUI(g.world)
+	g.marchand = NewMenuMarchand(g.world)
+	LoadMonsterSprites(g.world.Monsters)
+}
+
+// loadWorld reprend une partie chargée depuis une sauvegarde
+func (g *Game) loadWorld(w *World) {
+	g.world = w
+	g.inventaire = NewInventaireGUI(w)
+	g.marchand = NewMenuMarchand(w)
+	LoadMonsterSprites(w.Monsters)
+	AnimatePlayer(w)
+	g.scenes.Replace(g, &ExploreScene{})
+}
+
+// startGame quitte l'écran titre pour la map
+func (g *Game) startGame() {
+	fmt.Println("🎮 Start New Game !")
+	log.Printf("Graine de la partie : %d (rejouer avec -seed %d)", g.world.RNG.Seed, g.world.RNG.Seed)
+	g.scenes.Replace(g, &ExploreScene{})
+}
+
+// startDefender quitte l'écran titre pour une partie en mode Défenseur
+func (g *Game) startDefender() {
+	seed := g.seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	fmt.Println("🛡️ Mode Défenseur !")
+	log.Printf("Graine de la partie : %d (rejouer avec -seed %d)", seed, seed)
+	g.loadWorld(NewDefenderWorld(seed))
+}
+
 // Lancer la musique en boucle
 func playMusic() {
 	audioCtx = audio.NewContext(44100)
@@ -110,137 +147,120 @@ func playMusic() {
 	player.Play()
 }
 
-// Update gère la logique du jeu
-func (g *Game) Update() error {
-	// Gestion du combat
-	if inCombat {
-		UpdateCombat()
-		return nil
+// navDelta retourne le déplacement demandé dans une grille ce tick
+func (g *Game) navDelta() (dx, dy int) {
+	if g.actions.JustPressed(ActionNavLeft) {
+		dx--
 	}
-
-	// Gestion des entrées clavier/souris
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
-		return ebiten.Termination
+	if g.actions.JustPressed(ActionNavRight) {
+		dx++
 	}
+	if g.actions.JustPressed(ActionNavUp) {
+		dy--
+	}
+	if g.actions.JustPressed(ActionNavDown) {
+		dy++
+	}
+	return dx, dy
+}
 
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && g.inMenu {
-		x, y := ebiten.CursorPosition()
-		// Bouton Start
-		if x >= 90 && x <= 210 && y >= 520 && y <= 640 {
-			fmt.Println("🎮 Start New Game !")
-			g.inMenu = false
-		}
-		// Bouton Quitter
-		if x >= 240 && x <= 360 && y >= 520 && y <= 640 {
-			fmt.Println("👋 Quitter le jeu...")
-			os.Exit(0)
-		}
+// pressed indique si l'action est déclenchée au clavier, à la souris ou à la manette
+func (g *Game) pressed(a Action) bool {
+	return g.controls.Pressed(a) || g.gamepads.Pressed(a)
+}
+
+// Update lit les entrées et met à jour l'écran actif
+func (g *Game) Update() error {
+	g.gamepads.Update()
+	g.actions.Update(g.pressed)
+	g.inventaire.ShowFocus = g.gamepads.Connected()
+	g.marchand.ShowFocus = g.gamepads.Connected()
+
+	if err := g.scenes.Update(g); err != nil {
+		return err
 	}
+	g.autosave()
+	return nil
+}
 
-	// Mise à jour du joueur si hors menu
-	if !g.inMenu {
-		UpdatePlayer()
+// autosave écrit une sauvegarde automatique quand la partie le demande
+func (g *Game) autosave() {
+	if !g.world.TakeCheckpoint() {
+		return
+	}
+	if err := Autosave(g.world); err != nil {
+		log.Println("Sauvegarde automatique impossible :", err)
 	}
+}
 
-	// Animation vidéo menu
-	if g.inMenu && !g.videoEnded {
-		now := time.Now()
-		if now.Sub(g.lastFrameTime) >= g.frameDelay {
-			g.index++
-			if g.index >= len(g.frames) {
-				g.index = len(g.frames) - 1
-				g.videoEnded = true
-			}
-			g.lastFrameTime = now
-		}
+// offerRecovery propose de reprendre la dernière sauvegarde automatique
+// après un arrêt brutal du jeu
+func (g *Game) offerRecovery() {
+	d, err := LatestAutosave()
+	if err != nil {
+		log.Println("Aucune partie à récupérer :", err)
+		return
 	}
+	g.scenes.Push(g, &RecoverScene{save: d})
+}
 
+// updateCamera gère le zoom et le déplacement de la caméra
+func (g *Game) updateCamera() {
 	// Gestion du zoom
 	switch {
-	case ebiten.IsKeyPressed(ebiten.KeyKPAdd), ebiten.IsKeyPressed(ebiten.KeyEqual):
+	case g.actions.Held(ActionZoomIn):
 		g.camera.Zoom += 0.01
-	case ebiten.IsKeyPressed(ebiten.KeyKPSubtract), ebiten.IsKeyPressed(ebiten.KeyMinus):
+	case g.actions.Held(ActionZoomOut):
 		if g.camera.Zoom > 0.2 {
 			g.camera.Zoom -= 0.01
 		}
 	}
 
 	// Déplacement caméra
-	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+	if g.actions.Held(ActionCameraUp) {
 		g.camera.Y -= 5
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+	if g.actions.Held(ActionCameraDown) {
 		g.camera.Y += 5
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+	if g.actions.Held(ActionCameraLeft) {
 		g.camera.X -= 5
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+	if g.actions.Held(ActionCameraRight) {
 		g.camera.X += 5
 	}
-
-	// Mise à jour des entités
-	UpdateMonsters()
-	CheckCollisionWithPlayerCombat()
-	if g.inventaire != nil {
-		g.inventaire.Update()
-	}
-	if g.marchand != nil {
-		g.marchand.Update()
-	}
-
-	return nil
 }
 
-// Draw affiche le menu ou la map
+// Draw affiche les écrans de la pile
 func (g *Game) Draw(screen *ebiten.Image) {
-	if g.inMenu {
-		// Affichage du menu vidéo
-		if len(g.frames) > 0 && g.index < len(g.frames) {
-			opts := &ebiten.DrawImageOptions{}
-			opts.GeoM.Scale(
-				float64(screen.Bounds().Dx())/float64(g.frames[g.index].Bounds().Dx()),
-				float64(screen.Bounds().Dy())/float64(g.frames[g.index].Bounds().Dy()),
-			)
-			screen.DrawImage(g.frames[g.index], opts)
-		} else {
-			screen.Fill(color.Black)
-		}
-		if !g.videoEnded {
-			ebitenutil.DebugPrint(screen, "SAHARA DEFENDER\nVidéo en cours...")
-		} else {
-			ebitenutil.DebugPrint(screen, "SAHARA DEFENDER\nFin de la vidéo.\nClique Start ou Leave")
-		}
-		if inCombat {
-			DrawCombatScreen(screen)
-			return
-		}
-	} else {
-		// Affichage principal hors menu
-		DrawMap(screen)
-		g.marchand.Draw(screen)
-		g.player.DrawBars(screen)
-		DrawMonsters(screen)
-		DrawCombatMessage(screen)
-		DrawCombatScreen(screen)
-		g.inventaire.Draw(screen)
-	}
-
+	g.scenes.Draw(g, screen)
 }
 
 // Layout
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	// Layout de la fenêtre (conserve la taille demandée)
+	g.screenW, g.screenH = outsideWidth, outsideHeight
 	return outsideWidth, outsideHeight
 }
 
 func Main() {
+	seed := flag.Uint64("seed", 0, "graine du hasard des nouvelles parties (0 = aléatoire)")
+	flag.Parse()
+
+	// Vérifie les fichiers de données avant d'ouvrir la fenêtre
+	if err := CheckData(); err != nil {
+		log.Fatalf("Données du jeu invalides :\n%v", err)
+	}
+
 	// Initialisation du jeu
-	LoadMap()      // Charge la map
-	InitMonsters() // Initialise les monstres
+	LoadMap() // Charge la map
 
-	game := NewGame() // Crée l'instance principale
-	gameInstance = game
+	game := NewGame(*seed) // Crée l'instance principale
+
+	// La session précédente s'est arrêtée sans passer par "Quitter"
+	if BeginSession() {
+		game.offerRecovery()
+	}
 
 	ebiten.SetFullscreen(true)
 	ebiten.SetWindowTitle("SAHARA DEFENDER")
@@ -255,5 +275,5 @@ func Main() {
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
-
+	EndSession()
 }
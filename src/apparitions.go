@@ -0,0 +1,151 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// ----------------- Zones d'apparition -----------------
+// En plus des monstres placés au début de la partie (liste "spawns"), le
+// bestiaire décrit des zones ("zones") où les monstres réapparaissent : une
+// zone a un groupe de monstres pondéré, une population maximum, un délai
+// entre deux apparitions et une distance minimum au joueur. Les positions
+// sont tirées dans le flux aléatoire de la map : une graine rejoue les mêmes
+// apparitions. Les délais en cours sont sauvegardés avec la partie.
+
+// spawnAttempts est le nombre de positions essayées par tick
+const spawnAttempts = 8
+
+// SpawnChoice est un monstre possible d'une zone
+type SpawnChoice struct {
+	Monster string `json:"monster"` // Nom du type de monstre
+	Weight  int    `json:"weight"`  // Poids du tirage
+}
+
+// SpawnZone est une zone de la map où des monstres apparaissent
+type SpawnZone struct {
+	ID          string        `json:"id"`           // Identifiant (unique)
+	X           float64       `json:"x"`            // Centre de la zone (x)
+	Y           float64       `json:"y"`            // Centre de la zone (y)
+	Radius      float64       `json:"radius"`       // Rayon de la zone
+	Pool        []SpawnChoice `json:"pool"`         // Monstres possibles (pondérés)
+	Max         int           `json:"max"`          // Population maximum
+	Respawn     float64       `json:"respawn"`      // Délai entre deux apparitions, en secondes
+	MinDistance float64       `json:"min_distance"` // Distance minimum au joueur
+}
+
+// validate vérifie une zone d'apparition
+func (z *SpawnZone) validate(b *Bestiary) error {
+	switch {
+	case z.ID == "":
+		return errors.New("identifiant manquant")
+	case z.Radius <= 0:
+		return errors.New("rayon négatif ou nul")
+	case z.Max <= 0:
+		return errors.New("population maximum négative ou nulle")
+	case z.Respawn < 0:
+		return errors.New("délai négatif")
+	case z.MinDistance < 0:
+		return errors.New("distance minimum négative")
+	case len(z.Pool) == 0:
+		return errors.New("aucun monstre")
+	}
+	for _, c := range z.Pool {
+		if _, ok := b.Def(c.Monster); !ok {
+			return fmt.Errorf("monstre inconnu %q", c.Monster)
+		}
+		if c.Weight <= 0 {
+			return fmt.Errorf("poids invalide pour %q : %d", c.Monster, c.Weight)
+		}
+	}
+	return nil
+}
+
+// pick tire au sort un type de monstre de la zone
+func (z *SpawnZone) pick(r *rand.Rand) string {
+	total := 0
+	for _, c := range z.Pool {
+		total += c.Weight
+	}
+	n := r.IntN(total)
+	for _, c := range z.Pool {
+		if n < c.Weight {
+			return c.Monster
+		}
+		n -= c.Weight
+	}
+	return z.Pool[len(z.Pool)-1].Monster
+}
+
+// population compte les monstres de la zone encore sur la map
+func (w *World) population(zone string) int {
+	n := 0
+	for _, m := range w.Monsters {
+		if m.Zone == zone {
+			n++
+		}
+	}
+	return n
+}
+
+// fillZones remplit toutes les zones au début d'une partie
+func (w *World) fillZones() {
+	for i := range DefaultBestiary().Zones {
+		z := &DefaultBestiary().Zones[i]
+		for w.population(z.ID) < z.Max {
+			if !w.spawnIn(z) {
+				break
+			}
+		}
+	}
+}
+
+// updateSpawns fait réapparaître les monstres des zones dépeuplées : le
+// délai commence quand la zone passe sous sa population maximum
+func (w *World) updateSpawns() {
+	if w.Spawns == nil {
+		w.Spawns = map[string]int{}
+	}
+	for i := range DefaultBestiary().Zones {
+		z := &DefaultBestiary().Zones[i]
+		if w.population(z.ID) >= z.Max {
+			delete(w.Spawns, z.ID)
+			continue
+		}
+		next, ok := w.Spawns[z.ID]
+		if !ok {
+			w.Spawns[z.ID] = w.Tick + int(z.Respawn*TicksPerSecond)
+			continue
+		}
+		// Joueur trop proche : nouvel essai au tick suivant
+		if w.Tick >= next && w.spawnIn(z) {
+			delete(w.Spawns, z.ID)
+		}
+	}
+}
+
+// spawnIn fait apparaître un monstre dans la zone, loin du joueur.
+// Retourne false si aucune position ne convient.
+func (w *World) spawnIn(z *SpawnZone) bool {
+	r := w.RNG.World()
+	p := w.Player
+	def, _ := DefaultBestiary().Def(z.pick(r))
+	for i := 0; i < spawnAttempts; i++ {
+		angle := r.Float64() * 2 * math.Pi
+		dist := math.Sqrt(r.Float64()) * z.Radius // Répartition uniforme dans le disque
+		x := z.X + math.Cos(angle)*dist - def.Width/2
+		y := z.Y + math.Sin(angle)*dist - def.Height/2
+		dx := x + def.Width/2 - (p.PosX + p.Width/2)
+		dy := y + def.Height/2 - (p.PosY + p.Height/2)
+		if math.Hypot(dx, dy) < z.MinDistance || overlaps(p.PosX, p.PosY, p.Width, p.Height, x, y, def.Width, def.Height) {
+			continue
+		}
+		m := def.NewMonster(x, y)
+		m.Zone = z.ID
+		w.Monsters = append(w.Monsters, m)
+		return true
+	}
+	return false
+}
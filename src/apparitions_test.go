@@ -0,0 +1,102 @@
+package source
+
+import (
+	"math"
+	"testing"
+)
+
+// emptyZone retire de la map les monstres d'une zone
+func emptyZone(w *World, zone string) {
+	for _, m := range append([]*Monster(nil), w.Monsters...) {
+		if m.Zone == zone {
+			w.RemoveMonsterFromMap(m)
+		}
+	}
+}
+
+func TestSpawnRespawnDelay(t *testing.T) {
+	w := NewWorldSeed(4)
+	z := &DefaultBestiary().Zones[0]
+	if n := w.population(z.ID); n != z.Max {
+		t.Fatalf("zone %s : %d monstres au départ, attendu %d", z.ID, n, z.Max)
+	}
+	emptyZone(w, z.ID)
+
+	w.updateSpawns()
+	due := w.Spawns[z.ID]
+	if want := w.Tick + int(z.Respawn*TicksPerSecond); due != want {
+		t.Fatalf("réapparition au tick %d, attendu %d", due, want)
+	}
+	for w.Tick < due-1 {
+		w.Tick++
+		w.updateSpawns()
+	}
+	if n := w.population(z.ID); n != 0 {
+		t.Fatalf("%d monstres apparus avant le délai", n)
+	}
+
+	// Un monstre à la fois : le délai recommence après chaque apparition
+	w.Tick++
+	w.updateSpawns()
+	if n := w.population(z.ID); n != 1 {
+		t.Fatalf("%d monstres apparus au bout du délai, attendu 1", n)
+	}
+	w.Tick++
+	w.updateSpawns()
+	if want := w.Tick + int(z.Respawn*TicksPerSecond); w.Spawns[z.ID] != want {
+		t.Errorf("deuxième apparition au tick %d, attendu %d", w.Spawns[z.ID], want)
+	}
+	refill(t, w, z)
+	if _, ok := w.Spawns[z.ID]; ok {
+		t.Error("délai gardé pour une zone pleine")
+	}
+}
+
+// refill fait avancer les apparitions jusqu'à ce que la zone soit pleine
+func refill(t *testing.T, w *World, z *SpawnZone) {
+	t.Helper()
+	limit := w.Tick + z.Max*int(z.Respawn+5)*TicksPerSecond
+	for w.population(z.ID) < z.Max {
+		if w.Tick > limit {
+			t.Fatalf("zone %s toujours dépeuplée", z.ID)
+		}
+		w.Tick++
+		w.updateSpawns()
+	}
+}
+
+func TestSpawnMinDistance(t *testing.T) {
+	for seed := uint64(0); seed < 20; seed++ {
+		w := NewWorldSeed(seed)
+		p := w.Player
+		z := &DefaultBestiary().Zones[0]
+
+		// Joueur au centre de la zone : aucune position assez loin
+		p.PosX, p.PosY = z.X-p.Width/2, z.Y-p.Height/2
+		emptyZone(w, z.ID)
+		w.Spawns[z.ID] = w.Tick
+		for i := 0; i < TicksPerSecond; i++ {
+			w.Tick++
+			w.updateSpawns()
+		}
+		if n := w.population(z.ID); n != 0 {
+			t.Fatalf("graine %d : %d monstres apparus à côté du joueur", seed, n)
+		}
+		if _, ok := w.Spawns[z.ID]; !ok {
+			t.Fatalf("graine %d : apparition abandonnée au lieu d'être retentée", seed)
+		}
+
+		// Joueur au bord de la zone : les monstres apparaissent du côté opposé
+		p.PosX, p.PosY = z.X+z.Radius-p.Width/2, z.Y-p.Height/2
+		refill(t, w, z)
+		for _, m := range w.Monsters {
+			if m.Zone != z.ID {
+				continue
+			}
+			mx, my := m.center()
+			if d := math.Hypot(mx-z.X-z.Radius, my-z.Y); d < z.MinDistance {
+				t.Errorf("graine %d : %s apparu à %.0f du joueur (minimum %.0f)", seed, m.Name, d, z.MinDistance)
+			}
+		}
+	}
+}
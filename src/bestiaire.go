@@ -0,0 +1,199 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------- Bestiaire -----------------
+// Les types de monstres (statistiques, images, butin, comportement) et leur placement sur la map sont décrits dans
+// data/monstres.json. Ajouter un Dromadaire ou un Fennec = ajouter une
+// entrée dans ce fichier.
+
+// Comportements des monstres sur la map
+const (
+	BehaviourWander = "wander" // Se promène autour de son point de départ
+	BehaviourStatic = "static" // Reste immobile
+	BehaviourGuard  = "guard"  // Garde son point de départ
+	BehaviourMarch  = "march"  // Marche vers le camp (mode Défenseur, pas dans le bestiaire)
+)
+
+var monsterBehaviours = map[string]bool{
+	BehaviourWander: true,
+	BehaviourStatic: true,
+	BehaviourGuard:  true,
+}
+
+// MonsterDef décrit un type de monstre
+type MonsterDef struct {
+	Name       string         `json:"name"`       // Nom affiché (unique)
+	Sprites    []string       `json:"sprites"`    // Images de l'animation
+	Scale      float64        `json:"scale"`      // Facteur d'échelle des images
+	Width      float64        `json:"width"`      // Largeur de la zone de collision
+	Height     float64        `json:"height"`     // Hauteur de la zone de collision
+	Speed      float64        `json:"speed"`      // Vitesse de déplacement
+	Health     int            `json:"health"`     // Points de vie
+	Damage     int            `json:"damage"`     // Dégâts par attaque
+	Defense    int            `json:"defense"`    // Dégâts retirés à chaque coup reçu
+	Resistance float64        `json:"resistance"` // Part des dégâts ignorée (0 à 0,9)
+	XP         int            `json:"xp"`         // Expérience gagnée à la victoire
+	Loot       LootTable      `json:"loot"`       // Or et objets gagnés à la victoire
+	Effects    []StatusChance `json:"effects"`    // Effets infligés en touchant le joueur
+	Behaviour  string         `json:"behaviour"`  // Comportement sur la map
+
+	HomeRadius  float64 `json:"home_radius"`  // Rayon de promenade autour du point de départ
+	AggroRadius float64 `json:"aggro_radius"` // Distance à laquelle il poursuit le joueur (0 : jamais)
+	FleeHealth  float64 `json:"flee_health"`  // Part de la vie sous laquelle il fuit le joueur (0 : jamais)
+}
+
+// MonsterSpawn place un monstre sur la map au début de la partie
+type MonsterSpawn struct {
+	Monster string  `json:"monster"` // Nom du type de monstre
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+}
+
+// Bestiary regroupe les types de monstres et leur placement
+type Bestiary struct {
+	Monsters []MonsterDef   `json:"monsters"`
+	Spawns   []MonsterSpawn `json:"spawns"`
+	Zones    []SpawnZone    `json:"zones"` // Zones où les monstres réapparaissent
+
+	byName map[string]*MonsterDef
+}
+
+// DefaultBestiary retourne le bestiaire du jeu (data/monstres.json).
+func DefaultBestiary() *Bestiary {
+	return bestiaryData.get()
+}
+
+// LoadBestiary lit et valide un fichier de bestiaire
+func LoadBestiary(path string) (*Bestiary, error) {
+	return loadData[Bestiary](path)
+}
+
+// ParseBestiary décode et valide un bestiaire
+func ParseBestiary(name string, data []byte) (*Bestiary, error) {
+	return parseData[Bestiary](name, data)
+}
+
+// validate vérifie chaque type de monstre et chaque placement
+func (b *Bestiary) validate() error {
+	b.byName = map[string]*MonsterDef{}
+	var errs []error
+	for i := range b.Monsters {
+		d := &b.Monsters[i]
+		if err := d.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("monstre %d (%q) : %w", i+1, d.Name, err))
+			continue
+		}
+		if _, ok := b.byName[d.Name]; ok {
+			errs = append(errs, fmt.Errorf("monstre %q défini deux fois", d.Name))
+			continue
+		}
+		b.byName[d.Name] = d
+	}
+	for i, s := range b.Spawns {
+		if _, ok := b.byName[s.Monster]; !ok {
+			errs = append(errs, fmt.Errorf("placement %d : monstre inconnu %q", i+1, s.Monster))
+		}
+	}
+	zones := map[string]bool{}
+	for i := range b.Zones {
+		z := &b.Zones[i]
+		if err := z.validate(b); err != nil {
+			errs = append(errs, fmt.Errorf("zone %d (%q) : %w", i+1, z.ID, err))
+			continue
+		}
+		if zones[z.ID] {
+			errs = append(errs, fmt.Errorf("zone %q définie deux fois", z.ID))
+		}
+		zones[z.ID] = true
+	}
+	return errors.Join(errs...)
+}
+
+// validate vérifie les valeurs d'un type de monstre
+func (d *MonsterDef) validate() error {
+	switch {
+	case d.Name == "":
+		return errors.New("nom manquant")
+	case len(d.Sprites) == 0:
+		return errors.New("aucune image")
+	case d.Scale <= 0:
+		return errors.New("échelle négative ou nulle")
+	case d.Width <= 0 || d.Height <= 0:
+		return errors.New("taille négative ou nulle")
+	case d.Speed < 0:
+		return errors.New("vitesse négative")
+	case d.Health <= 0:
+		return errors.New("points de vie négatifs ou nuls")
+	case d.Damage < 0:
+		return errors.New("dégâts négatifs")
+	case d.Defense < 0:
+		return errors.New("défense négative")
+	case d.Resistance < 0 || d.Resistance > maxResistance:
+		return fmt.Errorf("résistance invalide : %v (entre 0 et %v)", d.Resistance, maxResistance)
+	case d.XP < 0:
+		return errors.New("expérience négative")
+	case !monsterBehaviours[d.Behaviour]:
+		return fmt.Errorf("comportement inconnu %q", d.Behaviour)
+	case d.HomeRadius < 0 || d.AggroRadius < 0:
+		return errors.New("rayon négatif")
+	case d.Behaviour == BehaviourWander && d.HomeRadius == 0:
+		return errors.New(`"home_radius" nécessaire pour se promener`)
+	case d.FleeHealth < 0 || d.FleeHealth >= 1:
+		return fmt.Errorf("flee_health invalide : %v (entre 0 et 1)", d.FleeHealth)
+	case d.FleeHealth > 0 && d.AggroRadius == 0:
+		return errors.New(`"aggro_radius" nécessaire pour fuir le joueur`)
+	}
+	if err := d.Loot.validate(); err != nil {
+		return fmt.Errorf("butin : %w", err)
+	}
+	for i := range d.Effects {
+		if err := d.Effects[i].validate(); err != nil {
+			return fmt.Errorf("effet %d : %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Def retourne le type de monstre portant ce nom
+func (b *Bestiary) Def(name string) (*MonsterDef, bool) {
+	d, ok := b.byName[name]
+	return d, ok
+}
+
+// NewMonster crée un monstre de ce type à la position (x, y)
+func (d *MonsterDef) NewMonster(x, y float64) *Monster {
+	return &Monster{
+		Name:        d.Name,
+		X:           x,
+		Y:           y,
+		W:           d.Width,
+		H:           d.Height,
+		SpritePaths: append([]string(nil), d.Sprites...),
+		Scale:       d.Scale,
+		Speed:       d.Speed,
+		Health:      d.Health,
+		MaxHealth:   d.Health,
+		Damage:      d.Damage,
+		Defense:     d.Defense,
+		Resistance:  d.Resistance,
+		Behaviour:   d.Behaviour,
+		HomeX:       x,
+		HomeY:       y,
+		TargetX:     x,
+		TargetY:     y,
+	}
+}
+
+// Spawn crée les monstres placés sur la map en début de partie
+func (b *Bestiary) Spawn() []*Monster {
+	ms := make([]*Monster, 0, len(b.Spawns))
+	for _, s := range b.Spawns {
+		d, _ := b.Def(s.Monster)
+		ms = append(ms, d.NewMonster(s.X, s.Y))
+	}
+	return ms
+}
@@ -0,0 +1,106 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// ----------------- Butin -----------------
+// Chaque type de monstre a une table de butin : une somme d'or tirée entre
+// un minimum et un maximum, un ou plusieurs tirages pondérés parmi les
+// objets courants, et des objets rares ayant chacun leur propre chance.
+
+// GoldRange est une somme d'or tirée entre Min et Max (inclus)
+type GoldRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// WeightedDrop est un résultat possible d'un tirage pondéré.
+// Un Item vide signifie "rien".
+type WeightedDrop struct {
+	Item   string `json:"item"`
+	Weight int    `json:"weight"`
+}
+
+// RareDrop est un objet rare tiré indépendamment des autres
+type RareDrop struct {
+	Item   string  `json:"item"`
+	Chance float64 `json:"chance"` // Probabilité entre 0 et 1
+}
+
+// LootTable décrit ce qu'un monstre peut laisser à sa mort
+type LootTable struct {
+	Gold  GoldRange      `json:"gold"`  // Or gagné
+	Rolls int            `json:"rolls"` // Nombre de tirages parmi Drops
+	Drops []WeightedDrop `json:"drops"` // Objets courants (pondérés)
+	Rare  []RareDrop     `json:"rare"`  // Objets rares
+}
+
+// Loot est le butin obtenu après une victoire
+type Loot struct {
+	Gold  int      // Or gagné
+	Items []string // Identifiants des objets gagnés
+}
+
+// validate vérifie la table de butin
+func (t *LootTable) validate() error {
+	switch {
+	case t.Gold.Min < 0 || t.Gold.Max < t.Gold.Min:
+		return fmt.Errorf("or invalide : %d à %d", t.Gold.Min, t.Gold.Max)
+	case t.Rolls < 0:
+		return errors.New("nombre de tirages négatif")
+	case t.Rolls > 0 && len(t.Drops) == 0:
+		return errors.New("tirages sans objets")
+	}
+	for _, d := range t.Drops {
+		if d.Weight <= 0 {
+			return fmt.Errorf("poids invalide pour %q : %d", d.Item, d.Weight)
+		}
+		if d.Item == "" {
+			continue
+		}
+		if _, ok := DefaultItems().Item(d.Item); !ok {
+			return fmt.Errorf("%w %q", ErrUnknownItem, d.Item)
+		}
+	}
+	for _, d := range t.Rare {
+		if d.Chance <= 0 || d.Chance > 1 {
+			return fmt.Errorf("chance invalide pour %q : %v", d.Item, d.Chance)
+		}
+		if _, ok := DefaultItems().Item(d.Item); !ok {
+			return fmt.Errorf("%w %q", ErrUnknownItem, d.Item)
+		}
+	}
+	return nil
+}
+
+// Roll tire le butin au sort
+func (t *LootTable) Roll(r *rand.Rand) Loot {
+	loot := Loot{Gold: t.Gold.Min + r.IntN(t.Gold.Max-t.Gold.Min+1)}
+
+	total := 0
+	for _, d := range t.Drops {
+		total += d.Weight
+	}
+	for i := 0; i < t.Rolls; i++ {
+		n := r.IntN(total)
+		for _, d := range t.Drops {
+			if n < d.Weight {
+				if d.Item != "" {
+					loot.Items = append(loot.Items, d.Item)
+				}
+				break
+			}
+			n -= d.Weight
+		}
+	}
+
+	for _, d := range t.Rare {
+		if r.Float64() < d.Chance {
+			loot.Items = append(loot.Items, d.Item)
+		}
+	}
+	return loot
+}
@@ -0,0 +1,100 @@
+package source
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testTable est une petite table de butin pour les tests
+var testTable = LootTable{
+	Gold:  GoldRange{Min: 10, Max: 20},
+	Rolls: 2,
+	Drops: []WeightedDrop{{Item: "plante_curative", Weight: 30}, {Item: "potion_magique", Weight: 10}, {Item: "", Weight: 60}},
+	Rare:  []RareDrop{{Item: "chapeau", Chance: 0.05}},
+}
+
+func TestLootRollSeed(t *testing.T) {
+	a, b := rand.New(rand.NewPCG(9, 2)), rand.New(rand.NewPCG(9, 2))
+	for i := 0; i < 100; i++ {
+		if la, lb := testTable.Roll(a), testTable.Roll(b); !reflect.DeepEqual(la, lb) {
+			t.Fatalf("même graine, butins différents : %+v et %+v", la, lb)
+		}
+	}
+}
+
+func TestLootRollGold(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	seen := map[int]bool{}
+	for i := 0; i < 2000; i++ {
+		gold := testTable.Roll(r).Gold
+		if gold < testTable.Gold.Min || gold > testTable.Gold.Max {
+			t.Fatalf("%d pièces hors de %d à %d", gold, testTable.Gold.Min, testTable.Gold.Max)
+		}
+		seen[gold] = true
+	}
+	if len(seen) != testTable.Gold.Max-testTable.Gold.Min+1 {
+		t.Errorf("sommes tirées : %d valeurs sur %d", len(seen), testTable.Gold.Max-testTable.Gold.Min+1)
+	}
+}
+
+func TestLootRollWeights(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	const n = 20000
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		for _, id := range testTable.Roll(r).Items {
+			counts[id]++
+		}
+	}
+	// Deux tirages par butin : 30 % et 10 % par tirage, chapeau à 5 %
+	for id, want := range map[string]float64{"plante_curative": 0.6, "potion_magique": 0.2, "chapeau": 0.05} {
+		if got := float64(counts[id]) / n; math.Abs(got-want) > 0.02 {
+			t.Errorf("%s : %.3f par butin, attendu %.2f", id, got, want)
+		}
+	}
+}
+
+func TestLootInventoryFull(t *testing.T) {
+	lost := 0
+	for seed := uint64(0); seed < 20; seed++ {
+		w := NewWorldSeed(seed)
+		p := w.Player
+		for len(p.Inventory) < p.Capacity {
+			if err := p.AjouterItem("epee"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		inventory := slices.Clone(p.Inventory)
+		i := slices.IndexFunc(w.Monsters, func(m *Monster) bool { return m.Name == "Hyène" })
+		hyena := w.Monsters[i]
+
+		// Butin attendu : même table, même flux
+		def, _ := DefaultBestiary().Def(hyena.Name)
+		want := def.Loot.Roll(NewWorldSeed(seed).RNG.Loot())
+
+		hyena.Health = 0
+		w.StartCombat(hyena)
+		w.EndCombat()
+		v := w.Victory
+		if v == nil || v.Gold != want.Gold || p.Money != 100+want.Gold {
+			t.Fatalf("graine %d : victoire %+v, attendu %+v", seed, v, want)
+		}
+		if len(v.Items) != 0 || !slices.Equal(v.Lost, want.Items) {
+			t.Errorf("graine %d : objets %v, perdus %v, attendu perdus %v", seed, v.Items, v.Lost, want.Items)
+		}
+		if len(v.Lost) > 0 && !strings.Contains(w.CombatMsg.Text, ItemName(v.Lost[0])) {
+			t.Errorf("graine %d : butin perdu absent du message %q", seed, w.CombatMsg.Text)
+		}
+		if !slices.Equal(p.Inventory, inventory) {
+			t.Errorf("graine %d : inventaire plein modifié : %v", seed, p.Inventory)
+		}
+		lost += len(v.Lost)
+	}
+	if lost == 0 {
+		t.Error("aucun objet perdu : le cas de l'inventaire plein n'est pas testé")
+	}
+}
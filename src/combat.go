@@ -3,7 +3,10 @@ package source
 import (
 	"fmt"
 	"image/color"
-	"time"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
@@ -11,203 +14,505 @@ import (
 )
 
 // ----------------- Variables de combat -----------------
-var inCombat bool
-var combatMonster *Monster
-var combatPlayerImage *ebiten.Image
 var combatFonts = basicfont.Face7x13
 
-var combatPlayerEntity *Entity
-var combatMonsterEntity *Entity
-
 var basicPunch = Weapon{Name: "Coup de poing", Damage: 10}
-var sword = Weapon{Name: "Épée", Damage: 40}
 
-// Message temporaire combat
-var combatTempMessage string
-var combatTempMsgTime time.Time
+// Rencontres de groupe
+const (
+	encounterRadius = 250 // Les monstres à cette distance du joueur rejoignent le combat
+	maxEnemies      = 4   // Nombre maximum de monstres dans un combat
+)
 
-// Tour par tour
-var playerTurn bool = true
-var bPressedLastFrame bool
-var vPressedLastFrame bool
-var shieldPotion int = 30 // valeur à adapter si besoin
-var healPotion int = 50   // valeur à adapter si besoin
+// Combat représente un combat en cours entre le joueur et un groupe de monstres
+type Combat struct {
+	Monsters    []*Monster // Monstres affrontés, dans l'ordre de jeu (leurs blessures restent sur la map)
+	TargetIndex int        // Monstre visé par le joueur
+	PlayerTurn  bool       // Tour par tour : true = au joueur de jouer
+	ItemsOpen   bool       // Menu des objets ouvert
 
-// Appui unique pour éviter multi-dégâts
-var aPressedLastFrame bool
-var ePressedLastFrame bool
-var spacePressedLastFrame bool
+	Energy    int            // Énergie pour les compétences
+	Cooldowns map[string]int // Tours de recharge restants par compétence
+}
 
-// ----------------- Début du combat -----------------
-func StartCombat(monster *Monster, playerImg *ebiten.Image) {
-	if monster == nil || playerImg == nil {
-		return
+// Target retourne le monstre visé par le joueur
+func (c *Combat) Target() *Monster {
+	return c.Monsters[c.TargetIndex]
+}
+
+// Living retourne les monstres encore en vie, dans l'ordre de jeu
+func (c *Combat) Living() []*Monster {
+	var living []*Monster
+	for _, m := range c.Monsters {
+		if m.Vivant() {
+			living = append(living, m)
+		}
 	}
+	return living
+}
 
-	// Démarre le combat entre le joueur et le monstre
-	inCombat = true
-	combatMonster = monster
-	combatPlayerImage = playerImg
-	playerTurn = true
+// SelectTarget vise le monstre i s'il est encore en vie
+func (c *Combat) SelectTarget(i int) bool {
+	if i < 0 || i >= len(c.Monsters) || !c.Monsters[i].Vivant() {
+		return false
+	}
+	c.TargetIndex = i
+	return true
+}
 
-	// Initialise l'entité joueur
-	combatPlayerEntity = &Entity{Name: "Joueur", Health: 100}
+// NextTarget retourne le monstre vivant suivant (dir = 1) ou précédent
+// (dir = -1) après la cible actuelle
+func (c *Combat) NextTarget(dir int) int {
+	n := len(c.Monsters)
+	for step := 1; step <= n; step++ {
+		i := ((c.TargetIndex+dir*step)%n + n) % n
+		if c.Monsters[i].Vivant() {
+			return i
+		}
+	}
+	return c.TargetIndex
+}
 
-	// Initialise l'entité monstre
-	hp := monster.Health
-	combatMonsterEntity = &Entity{Name: monster.Name, Health: hp, Damage: monster.Damage}
+// ----------------- Début du combat -----------------
+// StartCombat démarre un combat contre un ou plusieurs monstres. Les plus
+// rapides jouent en premier ; le premier monstre donné est visé.
+func (w *World) StartCombat(monsters ...*Monster) {
+	if len(monsters) == 0 || monsters[0] == nil {
+		return
+	}
+	first := monsters[0]
+	order := append([]*Monster(nil), monsters...)
+	sort.SliceStable(order, func(i, j int) bool { return order[i].Speed > order[j].Speed })
+
+	w.Combat = &Combat{
+		Monsters:   order,
+		PlayerTurn: true,
+		Energy:     combatEnergy,
+		Cooldowns:  map[string]int{},
+	}
+	w.Combat.TargetIndex = slices.Index(order, first)
+	if len(order) > 1 {
+		w.CombatMsg = w.say(fmt.Sprintf("%d monstres vous attaquent !", len(order)))
+	}
 }
 
 // ----------------- Fin du combat -----------------
-func EndCombat() {
-	inCombat = false
-	combatMonster = nil
-	combatPlayerImage = nil
-	combatPlayerEntity = nil
-	combatMonsterEntity = nil
-	aPressedLastFrame = false
-	ePressedLastFrame = false
-	spacePressedLastFrame = false
+// EndCombat termine le combat (victoire ou fuite). Les monstres vaincus
+// donnent leur butin et quittent la map, même après une fuite.
+func (w *World) EndCombat() {
+	w.endCombat(true)
 }
 
-// ----------------- Mise à jour du combat -----------------
-func UpdateCombat() {
-	if !inCombat {
+// endCombat termine le combat ; sans reward (défaite), les monstres
+// vaincus quittent la map sans donner de butin
+func (w *World) endCombat(reward bool) {
+	c := w.Combat
+	if c == nil {
 		return
 	}
-
-	// Quitter combat avec SPACE
-	spacePressed := ebiten.IsKeyPressed(ebiten.KeySpace)
-	if spacePressed && !spacePressedLastFrame {
-		EndCombat()
-		return
+	w.Combat = nil
+	for _, m := range c.Monsters {
+		RetirerStatuts(m)
 	}
-	spacePressedLastFrame = spacePressed
+	RetirerStatuts(w.Player) // Avant de recalculer les statistiques (niveau gagné)
 
-	// Si le joueur n'a plus de vie ni de shield, impossible d'attaquer
-	if gameInstance != nil && gameInstance.player != nil && gameInstance.player.Life == 0 && gameInstance.player.Shield == 0 {
-		combatTempMessage = "Vous avez perdu. Impossible d'envoyer une attaque. Essayez une prochaine fois."
-		combatTempMsgTime = time.Now()
-		return
+	var dead []*Monster
+	for _, m := range c.Monsters {
+		if !m.Vivant() {
+			dead = append(dead, m)
+		}
 	}
-	if playerTurn {
-		// Attaque simple "A"
-		aPressed := ebiten.IsKeyPressed(ebiten.KeyQ)
-		if aPressed && !aPressedLastFrame && combatMonsterEntity.Health > 0 {
-			combatMonsterEntity.TakeDamage(basicPunch.Damage)
-			playerTurn = false // fin du tour → passe au monstre
+	switch {
+	case len(dead) == 0:
+	case reward:
+		w.winCombat(dead)
+	default:
+		for _, m := range dead {
+			w.RemoveMonsterFromMap(m)
 		}
-		aPressedLastFrame = aPressed
-
-		// Attaque épée "E" ou épée améliorée
-		ePressed := ebiten.IsKeyPressed(ebiten.KeyE)
-		if ePressed && !ePressedLastFrame && combatMonsterEntity.Health > 0 {
-			var hasSword, hasSwordPlus bool
-			if gameInstance != nil && gameInstance.player != nil {
-				for _, item := range gameInstance.player.Inventory {
-					if item == "Épée" {
-						hasSword = true
-					}
-					if item == "Épée améliorée" {
-						hasSwordPlus = true
-					}
-				}
-			}
-			if hasSwordPlus {
-				combatMonsterEntity.TakeDamage(75) // Dégâts épée améliorée
-				playerTurn = false
-			} else if hasSword {
-				combatMonsterEntity.TakeDamage(sword.Damage)
-				playerTurn = false
+	}
+}
+
+// ----------------- Mise à jour du combat -----------------
+func (w *World) updateCombat(in Input) {
+	c := w.Combat
+	p := w.Player
+
+	if c.PlayerTurn && c.ItemsOpen {
+		w.updateCombatItems(in)
+	} else if c.PlayerTurn {
+		// Menu des objets
+		if in.CombatItems {
+			if len(p.Consommables()) == 0 {
+				w.CombatMsg = w.say("Aucun objet utilisable !")
 			} else {
-				fmt.Println("Vous n'avez pas d'épée !")
+				c.ItemsOpen = true
 			}
+			return
 		}
-		ePressedLastFrame = ePressed
 
-		// Potion de shield "B"
-		bPressed := ebiten.IsKeyPressed(ebiten.KeyB)
-		if bPressed && !bPressedLastFrame {
-			if gameInstance != nil && gameInstance.player != nil {
-				gameInstance.player.Soigner(shieldPotion)
-			}
-			playerTurn = false
+		// Fuite (consomme le tour si elle rate)
+		if in.Flee {
+			w.flee()
+			return
 		}
-		bPressedLastFrame = bPressed
 
-		// Potion de soin "V"
-		vPressed := ebiten.IsKeyPressed(ebiten.KeyV)
-		if vPressed && !vPressedLastFrame {
-			if gameInstance != nil && gameInstance.player != nil {
-				gameInstance.player.Soigner(healPotion)
+		// Choix de la cible (ne termine pas le tour)
+		if in.SelectTarget {
+			c.SelectTarget(in.TargetSlot)
+		}
+
+		// Une seule action par tour : compétence, coup de poing ou épée
+		if skills := p.Competences(); in.UseSkill && in.SkillSlot >= 0 && in.SkillSlot < len(skills) {
+			// Compétences débloquées
+			if msg, ok := w.useSkill(skills[in.SkillSlot]); ok {
+				c.PlayerTurn = false
+			} else {
+				w.CombatMsg = w.say(msg)
+			}
+		} else if in.Punch {
+			// Attaque simple
+			w.frapper(p.Strike(basicPunch.Name, basicPunch.Damage), c.Target())
+			c.PlayerTurn = false // fin du tour → passe au monstre
+		} else if in.Sword {
+			// Attaque avec l'arme équipée
+			if weapon, ok := p.Arme(); ok {
+				w.frapper(p.Strike(weapon.Name, weapon.Damage), c.Target())
+				c.PlayerTurn = false
+			} else {
+				w.CombatMsg = w.say("Aucune arme équipée !")
 			}
-			playerTurn = false
 		}
-		vPressedLastFrame = vPressed
 
 	} else {
-		// --- Tour du monstre ---
-		if combatMonsterEntity.Health > 0 {
-			damage := combatMonsterEntity.Damage
-			// Applique les dégâts au joueur réel
-			if gameInstance != nil && gameInstance.player != nil {
-				oldShield := gameInstance.player.Shield
-				oldLife := gameInstance.player.Life
-				gameInstance.player.PrendreDegats(damage)
-				lostShield := oldShield - gameInstance.player.Shield
-				lostLife := oldLife - gameInstance.player.Life
-				if lostShield > 0 && lostLife > 0 {
-					combatTempMessage = fmt.Sprintf("Le monstre inflige %d dégâts ! Shield -%d, Vie -%d", damage, lostShield, lostLife)
-				} else if lostShield > 0 {
-					combatTempMessage = fmt.Sprintf("Le monstre inflige %d dégâts ! Shield -%d", damage, lostShield)
-				} else if lostLife > 0 {
-					combatTempMessage = fmt.Sprintf("Le monstre inflige %d dégâts ! Vie -%d", damage, lostLife)
-				} else {
-					combatTempMessage = "Le monstre attaque !"
-				}
-				combatTempMsgTime = time.Now()
-			} else {
-				combatPlayerEntity.TakeDamage(damage)
-				combatTempMessage = fmt.Sprintf("Le monstre inflige %d dégâts !", damage)
-				combatTempMsgTime = time.Now()
+		// --- Tour des monstres : chacun joue dans l'ordre ---
+		for _, m := range c.Monsters {
+			if m.Vivant() && p.Vivant() {
+				w.monsterTurn(m)
 			}
-			fmt.Printf("%s attaque le joueur et inflige %d dégâts !\n", combatMonster.Name, damage)
 		}
-		playerTurn = true // fin du tour → revient au joueur
-	}
-
-	// Fin combat si monstre mort
-	if combatMonsterEntity.Health <= 0 {
-		// Récompense selon le monstre vaincu
-		if gameInstance != nil && gameInstance.player != nil && combatMonster != nil {
-			switch combatMonster.Name {
-			case "Scorpion":
-				gameInstance.player.Money += 50
-				combatTempMessage = "Bravo ! Vous avez gagné 50 pièces."
-				combatTempMsgTime = time.Now()
-			case "Serpent":
-				gameInstance.player.Money += 500
-				combatTempMessage = "Bravo ! Vous avez gagné 100 pièces."
-				combatTempMsgTime = time.Now()
-			case "Hyène":
-				gameInstance.player.Money += 1000
-				combatTempMessage = "Bravo ! Vous avez gagné 200 pièces."
-				combatTempMsgTime = time.Now()
+		c.nextTurn()
+		if p.Vivant() {
+			w.startPlayerTurn() // fin du tour → revient au joueur
+		}
+	}
+
+	// Fin du combat : défaite si le joueur est mort, victoire si tous les monstres le sont
+	switch {
+	case !p.Vivant():
+		w.defeat()
+	case len(c.Living()) == 0:
+		w.EndCombat()
+	case !c.Target().Vivant():
+		c.TargetIndex = c.NextTarget(1)
+	}
+}
+
+// monsterTurn fait jouer un monstre : attaque (sauf s'il est étourdi ou
+// aveuglé), puis ses effets de statut avancent
+func (w *World) monsterTurn(m *Monster) {
+	switch {
+	case m.Statuts.Has(StatusStun):
+		w.combatLog(fmt.Sprintf("%s est étourdi et passe son tour !", m.Name))
+	case m.Statuts.Has(StatusBlind):
+		w.combatLog(fmt.Sprintf("%s, aveuglé, rate son attaque !", m.Name))
+	default:
+		// Applique les dégâts au joueur
+		w.frapper(m.Strike(), w.Player)
+		w.inflictStatuses(m)
+	}
+	if m.Vivant() {
+		w.combatLog(TickStatuts(m)...)
+	}
+}
+
+// frapper résout un coup porté à un combattant (joueur ou monstre)
+func (w *World) frapper(s Strike, target Combatant) DamageResult {
+	res := Attack(w.RNG.Combat(), s, target)
+	w.combatLog(res.String())
+	return res
+}
+
+// startPlayerTurn rend la main au joueur après le tour du monstre : ses
+// effets de statut avancent, et s'il est étourdi le monstre rejoue
+func (w *World) startPlayerTurn() {
+	p := w.Player
+	stunned := p.Statuts.Has(StatusStun)
+	w.combatLog(TickStatuts(p)...)
+	if stunned {
+		w.combatLog(p.Name + " est étourdi et passe son tour !")
+		return
+	}
+	w.Combat.PlayerTurn = true
+}
+
+// inflictStatuses tire au sort les effets que le monstre inflige en touchant
+func (w *World) inflictStatuses(m *Monster) {
+	def, ok := DefaultBestiary().Def(m.Name)
+	if !ok {
+		return
+	}
+	for i := range def.Effects {
+		if s, ok := def.Effects[i].Roll(w.RNG.Combat()); ok {
+			w.combatLog(AppliquerStatut(w.Player, s))
+		}
+	}
+}
+
+// combatLog ajoute des messages au message de combat du tick courant
+func (w *World) combatLog(msgs ...string) {
+	for _, msg := range msgs {
+		if msg == "" {
+			continue
+		}
+		if w.CombatMsg.Tick == w.Tick && w.CombatMsg.Text != "" {
+			msg = w.CombatMsg.Text + " | " + msg
+		}
+		w.CombatMsg = w.say(msg)
+	}
+}
+
+// nextTurn fait avancer les recharges et l'énergie à la fin du tour du monstre
+func (c *Combat) nextTurn() {
+	for id, turns := range c.Cooldowns {
+		if turns <= 1 {
+			delete(c.Cooldowns, id)
+		} else {
+			c.Cooldowns[id] = turns - 1
+		}
+	}
+	if c.Energy < combatMaxEnergy {
+		c.Energy++
+	}
+}
+
+// updateCombatItems gère le menu des objets : utiliser un consommable
+// applique son effet, le retire de l'inventaire et termine le tour
+func (w *World) updateCombatItems(in Input) {
+	c := w.Combat
+	p := w.Player
+	if in.CombatItems {
+		c.ItemsOpen = false
+		return
+	}
+	if !in.UseItem || in.ItemSlot < 0 || in.ItemSlot >= len(p.Inventory) {
+		return
+	}
+	def, ok := DefaultItems().Item(p.Inventory[in.ItemSlot].ID)
+	if !ok || def.Category != CategoryConsumable {
+		w.CombatMsg = w.say("Objet inutilisable en combat !")
+		return
+	}
+	msg := p.UtiliserItem(def)
+	p.retirerCase(in.ItemSlot)
+	w.CombatMsg = w.say(msg)
+	c.ItemsOpen = false
+	c.PlayerTurn = false
+}
+
+// Victory résume le butin gagné lors de la dernière victoire
+type Victory struct {
+	Monsters []string // Noms des monstres vaincus
+	Gold     int      // Or gagné
+	XP       int      // Expérience gagnée
+	Levels   int      // Niveaux gagnés
+	Items    []string // Objets ajoutés à l'inventaire
+	Lost     []string // Objets perdus (ajout refusé par l'inventaire)
+}
+
+// winCombat donne le butin des monstres vaincus et les retire de la map.
+// Appelée par EndCombat, une fois les effets de statut retirés.
+func (w *World) winCombat(dead []*Monster) {
+	p := w.Player
+	v := &Victory{}
+	for _, m := range dead {
+		var loot Loot
+		if def, ok := DefaultBestiary().Def(m.Name); ok {
+			loot = def.Loot.Roll(w.RNG.Loot())
+			v.XP += def.XP
+		}
+		v.Monsters = append(v.Monsters, m.Name)
+		v.Gold += loot.Gold
+		for _, id := range loot.Items {
+			if err := p.AjouterItem(id); err != nil {
+				v.Lost = append(v.Lost, id)
+				continue
 			}
+			v.Items = append(v.Items, id)
+		}
+		w.RemoveMonsterFromMap(m)
+	}
+	p.Money += v.Gold
+	v.Levels = p.GagnerXP(v.XP)
+
+	w.CombatMsg = w.say(fmt.Sprintf("Bravo ! Vous avez gagné %d pièces.", v.Gold))
+	if len(v.Lost) > 0 {
+		var lost []string
+		for _, id := range v.Lost {
+			lost = append(lost, ItemName(id))
 		}
-		RemoveMonsterFromMap(combatMonster)
-		EndCombat()
+		w.combatLog("Inventaire plein, butin perdu : " + strings.Join(lost, ", "))
 	}
+	w.Victory = v
+	w.checkpoint = true
 }
 
 // ----------------- Dessin de la fenêtre de combat -----------------
-func DrawCombatScreen(screen *ebiten.Image) {
-	if !inCombat || combatMonsterEntity == nil || combatPlayerEntity == nil {
+
+// Menu des objets, au centre de la fenêtre de combat
+const (
+	combatWinW      = 1000
+	combatWinH      = 400
+	combatItemsW    = 320
+	combatItemRowH  = 28
+	combatItemsTopY = 60
+)
+
+// Emplacements des monstres, à gauche de la fenêtre de combat
+const (
+	combatTargetW    = 170
+	combatTargetH    = 180
+	combatTargetTopY = 145
+)
+
+// combatTargetRect retourne la position de l'emplacement du monstre i
+func combatTargetRect(i, screenW, screenH int) (x, y int) {
+	x = (screenW-combatWinW)/2 + 20 + i*combatTargetW
+	y = (screenH-combatWinH)/2 + combatTargetTopY
+	return x, y
+}
+
+// CombatTargetAt retourne le monstre sous le curseur, ou -1
+func CombatTargetAt(mx, my, screenW, screenH, count int) int {
+	for i := 0; i < count; i++ {
+		x, y := combatTargetRect(i, screenW, screenH)
+		if mx >= x && mx < x+combatTargetW-10 && my >= y && my < y+combatTargetH {
+			return i
+		}
+	}
+	return -1
+}
+
+// drawTargets dessine les monstres du combat, leur vie et leurs effets.
+// La cible du joueur est entourée ; les monstres vaincus sont grisés.
+func drawTargets(screen *ebiten.Image, w *World) {
+	c := w.Combat
+	screenW, screenH := screen.Size()
+	for i, m := range c.Monsters {
+		x, y := combatTargetRect(i, screenW, screenH)
+		if i == c.TargetIndex && m.Vivant() {
+			drawOutline(screen, x, y, combatTargetW-10, combatTargetH, color.RGBA{218, 165, 32, 255})
+		}
+		if img := monsterFrame(m, w.Tick); img != nil {
+			opts := &ebiten.DrawImageOptions{}
+			opts.GeoM.Translate(float64(x+5), float64(y+5))
+			if !m.Vivant() {
+				opts.ColorScale.Scale(0.4, 0.4, 0.4, 0.6)
+			}
+			screen.DrawImage(img, opts)
+		}
+		if !m.Vivant() {
+			text.Draw(screen, m.Name+" : vaincu", combatFonts, x+5, y+135, color.RGBA{90, 90, 90, 255})
+			continue
+		}
+		text.Draw(screen, m.Name+": "+itoa(m.Health)+"/"+itoa(m.MaxHealth), combatFonts, x+5, y+135, color.RGBA{255, 0, 0, 255})
+		drawStatusIcons(screen, m.Statuts, x+5, y+145)
+	}
+}
+
+// combatItemRect retourne la position de la ligne i du menu des objets
+func combatItemRect(i, screenW, screenH int) (x, y int) {
+	x = (screenW-combatWinW)/2 + combatWinW/2 - combatItemsW/2
+	y = (screenH-combatWinH)/2 + combatItemsTopY + 30 + i*combatItemRowH
+	return x, y
+}
+
+// CombatItemAt retourne la ligne du menu des objets sous le curseur, ou -1
+func CombatItemAt(mx, my, screenW, screenH, count int) int {
+	for i := 0; i < count; i++ {
+		x, y := combatItemRect(i, screenW, screenH)
+		if mx >= x+10 && mx <= x+combatItemsW-10 && my >= y && my <= y+combatItemRowH-4 {
+			return i
+		}
+	}
+	return -1
+}
+
+// drawSkills affiche l'énergie et les compétences débloquées avec leur
+// touche, leur coût et leur recharge
+func drawSkills(screen *ebiten.Image, w *World, controls *Bindings, x, y int) {
+	c := w.Combat
+	p := w.Player
+	text.Draw(screen, fmt.Sprintf("Énergie: %d/%d", c.Energy, combatMaxEnergy), combatFonts, x, y, color.RGBA{139, 69, 19, 255})
+	for i, d := range p.Competences() {
+		key := "-"
+		if i < len(skillActions) {
+			key = controls.Label(skillActions[i])
+		}
+		state := "prête"
+		clr := color.Color(color.Black)
+		if turns := c.Cooldowns[d.ID]; turns > 0 {
+			state = fmt.Sprintf("recharge %d tour(s)", turns)
+			clr = color.RGBA{120, 120, 120, 255}
+		} else if c.Energy < d.Cost {
+			state = "énergie insuffisante"
+			clr = color.RGBA{120, 120, 120, 255}
+		}
+		line := fmt.Sprintf("%s = %s (%d én.) : %s", key, d.Name, d.Cost, state)
+		text.Draw(screen, line, combatFonts, x, y+25+i*20, clr)
+	}
+}
+
+// drawCombatItems dessine la liste des consommables possédés. focus est
+// la ligne sélectionnée au clavier ou à la manette (-1 : aucune).
+func drawCombatItems(screen *ebiten.Image, w *World, focus int) {
+	p := w.Player
+	screenW, screenH := screen.Size()
+	cases := p.Consommables()
+	brown := color.RGBA{101, 67, 33, 255}
+
+	x, y := combatItemRect(0, screenW, screenH)
+	y -= 30
+	h := 40 + len(cases)*combatItemRowH + 30
+	drawRoundedRect(screen, x, y, combatItemsW, h, 12, color.RGBA{210, 180, 140, 240})
+	text.Draw(screen, "Objets", combatFonts, x+combatItemsW/2-21, y+20, brown)
+
+	mx, my := ebiten.CursorPosition()
+	hover := CombatItemAt(mx, my, screenW, screenH, len(cases))
+	for i, idx := range cases {
+		stack := p.Inventory[idx]
+		rowX, rowY := combatItemRect(i, screenW, screenH)
+		slotColor := color.RGBA{184, 134, 11, 200}
+		if i == hover || i == focus {
+			slotColor = color.RGBA{218, 165, 32, 230}
+		}
+		drawRoundedRect(screen, rowX+10, rowY, combatItemsW-20, combatItemRowH-4, 8, slotColor)
+		text.Draw(screen, fmt.Sprintf("%s x%d", ItemName(stack.ID), stack.Count), combatFonts, rowX+20, rowY+17, brown)
+	}
+
+	// Description de l'objet survolé ou sélectionné
+	selected := hover
+	if selected < 0 {
+		selected = focus
+	}
+	if selected >= 0 && selected < len(cases) {
+		if def, ok := DefaultItems().Item(p.Inventory[cases[selected]].ID); ok {
+			text.Draw(screen, def.Description, combatFonts, x+15, y+h-12, brown)
+		}
+	}
+}
+
+// DrawCombatScreen dessine la fenêtre de combat. itemFocus est la ligne
+// sélectionnée dans le menu des objets.
+func DrawCombatScreen(screen *ebiten.Image, w *World, playerImg *ebiten.Image, controls *Bindings, itemFocus int) {
+	if w.Combat == nil {
 		return
 	}
+	c := w.Combat
+	p := w.Player
 
 	screenW, screenH := screen.Size()
-	winW, winH := 1000, 400
+	winW, winH := combatWinW, combatWinH
 	x := (screenW - winW) / 2
 	y := (screenH - winH) / 2
 
@@ -219,69 +524,96 @@ func DrawCombatScreen(screen *ebiten.Image) {
 	screen.DrawImage(win, opts)
 
 	// PV affichés
-	text.Draw(screen, "Combat contre "+combatMonsterEntity.Name, combatFonts, x+20, y+40, color.Black)
-	if gameInstance != nil && gameInstance.player != nil {
-		text.Draw(screen, "PV Joueur: "+itoa(gameInstance.player.Life)+"/"+itoa(gameInstance.player.MaxLife), combatFonts, x+20, y+80, color.RGBA{0, 0, 255, 255})
-		text.Draw(screen, "Shield: "+itoa(gameInstance.player.Shield)+"/"+itoa(gameInstance.player.MaxShield), combatFonts, x+20, y+110, color.RGBA{0, 128, 255, 200})
-		// Message temporaire dégâts
-		if combatTempMessage != "" && time.Since(combatTempMsgTime).Seconds() < 2 {
-			text.Draw(screen, combatTempMessage, combatFonts, x+20, y+140, color.RGBA{255, 0, 0, 255})
-		}
-		// Message de défaite
-		if gameInstance.player.Life == 0 {
-			text.Draw(screen, "Vous avez perdu, essayez une prochaine fois !", combatFonts, x+winW/2-200, y+winH/2, color.RGBA{255, 0, 0, 255})
-		}
+	var names []string
+	for _, m := range c.Monsters {
+		names = append(names, m.Name)
 	}
-	text.Draw(screen, "PV "+combatMonsterEntity.Name+": "+itoa(combatMonsterEntity.Health), combatFonts, x+20, y+120, color.RGBA{255, 0, 0, 255})
-
-	// Monstre à gauche
-	if combatMonster != nil && len(combatMonster.Sprites) > 0 {
-		img := combatMonster.Sprites[combatMonster.Index%len(combatMonster.Sprites)]
-		opts := &ebiten.DrawImageOptions{}
-		opts.GeoM.Translate(float64(x+50), float64(y+150))
-		screen.DrawImage(img, opts)
+	text.Draw(screen, "Combat contre "+strings.Join(names, ", "), combatFonts, x+20, y+40, color.Black)
+	text.Draw(screen, "PV Joueur: "+itoa(p.Life)+"/"+itoa(p.MaxLife), combatFonts, x+20, y+80, color.RGBA{0, 0, 255, 255})
+	text.Draw(screen, "Shield: "+itoa(p.Shield)+"/"+itoa(p.MaxShield), combatFonts, x+20, y+110, color.RGBA{0, 128, 255, 200})
+	// Message temporaire dégâts
+	if w.MessageVisible(w.CombatMsg) {
+		text.Draw(screen, w.CombatMsg.Text, combatFonts, x+20, y+140, color.RGBA{255, 0, 0, 255})
+	}
+	// Message de défaite
+	if p.Life == 0 {
+		text.Draw(screen, "Vous avez perdu, essayez une prochaine fois !", combatFonts, x+winW/2-200, y+winH/2, color.RGBA{255, 0, 0, 255})
 	}
 
+	drawStatusIcons(screen, p.Statuts, x+220, y+66)
+	drawSkills(screen, w, controls, x+winW-330, y+40)
+
+	// Monstres à gauche
+	drawTargets(screen, w)
+
 	// Joueur à droite
-	if combatPlayerImage != nil {
+	if playerImg != nil {
 		opts := &ebiten.DrawImageOptions{}
 		opts.GeoM.Translate(float64(x+winW-150), float64(y+150))
-		screen.DrawImage(combatPlayerImage, opts)
+		screen.DrawImage(playerImg, opts)
+	}
+
+	if c.ItemsOpen {
+		drawCombatItems(screen, w, itemFocus)
 	}
 
 	// Instructions
-	text.Draw(screen, "A = Coup de point ! | E = Épée ! | SPACE = Fuir !", combatFonts, x+20, y+winH-30, color.Black)
+	help := fmt.Sprintf("%s = Coup de point ! | %s = Épée ! | %s = Objets | %s = Fuir (%d %%)",
+		controls.Label(ActionAttack), controls.Label(ActionUseSword), controls.Label(ActionCombatItems),
+		strings.ToUpper(controls.Label(ActionFlee)), int(w.FleeChance(c.Monsters...)*100))
+	if len(c.Monsters) > 1 {
+		help += fmt.Sprintf(" | %s/%s = Cible", controls.Label(ActionNavLeft), controls.Label(ActionNavRight))
+	}
+	text.Draw(screen, help, combatFonts, x+20, y+winH-30, color.Black)
 }
 
 // ----------------- Collision pour lancer combat -----------------
-func CheckCollisionWithPlayerCombat() {
-	if inCombat || len(currentSprites) == 0 {
+func (w *World) checkCollisionWithPlayerCombat() {
+	if w.Combat != nil {
 		return
 	}
 
-	playerW, playerH := 64.0, 64.0
-	for _, m := range monsters {
-		if len(m.Sprites) == 0 {
-			continue
-		}
-		w, h := m.Sprites[0].Size()
-		if playerX < m.X+float64(w) && playerX+playerW > m.X &&
-			playerY < m.Y+float64(h) && playerY+playerH > m.Y {
-			StartCombat(m, currentSprites[index])
+	p := w.Player
+	if w.Immune() {
+		return
+	}
+	for _, m := range w.Monsters {
+		if overlaps(p.PosX, p.PosY, p.Width, p.Height, m.X, m.Y, m.W, m.H) {
+			w.StartCombat(w.encounter(m)...)
 			return
 		}
 	}
 }
 
+// encounter retourne le groupe qui attaque le joueur : le monstre touché,
+// puis les plus proches à moins de encounterRadius du joueur
+func (w *World) encounter(first *Monster) []*Monster {
+	p := w.Player
+	px, py := p.PosX+p.Width/2, p.PosY+p.Height/2
+	dist := func(m *Monster) float64 {
+		mx, my := m.center()
+		return math.Hypot(mx-px, my-py)
+	}
+	var near []*Monster
+	for _, m := range w.Monsters {
+		if m != first && dist(m) < encounterRadius {
+			near = append(near, m)
+		}
+	}
+	sort.SliceStable(near, func(i, j int) bool { return dist(near[i]) < dist(near[j]) })
+	group := append([]*Monster{first}, near...)
+	return group[:min(len(group), maxEnemies)]
+}
+
 // ----------------- Supprimer monstre de la map -----------------
-func RemoveMonsterFromMap(monster *Monster) {
+func (w *World) RemoveMonsterFromMap(monster *Monster) {
 	newList := []*Monster{}
-	for _, m := range monsters {
+	for _, m := range w.Monsters {
 		if m != monster {
 			newList = append(newList, m)
 		}
 	}
-	monsters = newList
+	w.Monsters = newList
 }
 
 // ----------------- Int -> string -----------------
@@ -0,0 +1,214 @@
+package source
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSkillActionsCoverSkills(t *testing.T) {
+	skills := DefaultSkills().Skills
+	if len(skills) > len(skillActions) {
+		t.Fatalf("%d compétences pour %d touches", len(skills), len(skillActions))
+	}
+	b := DefaultBindings()
+	for i := range skills {
+		a := skillActions[i]
+		if len(b.Keys[a]) == 0 || len(b.Gamepad.Buttons[a]) == 0 {
+			t.Errorf("%s (%s) : pas de touche ou de bouton par défaut", a, skills[i].ID)
+		}
+	}
+}
+
+func TestCombatOneActionPerTurn(t *testing.T) {
+	w := NewWorldSeed(1)
+	p := w.Player
+	if err := p.AjouterItem("epee"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Equiper("epee"); err != nil {
+		t.Fatal(err)
+	}
+	m := w.Monsters[0]
+	w.StartCombat(m)
+	c := w.Combat
+	health, energy := m.Health, c.Energy
+
+	// Compétence, coup de poing et épée sur le même tick : seule la compétence joue
+	w.Update(Input{UseSkill: true, SkillSlot: 0, Punch: true, Sword: true})
+	if c.PlayerTurn {
+		t.Fatal("le tour du joueur devrait être terminé")
+	}
+	if m.Health != health {
+		t.Errorf("le monstre a perdu %d PV en plus du jet de sable", health-m.Health)
+	}
+	if !m.Statuts.Has(StatusBlind) || c.Energy != energy-DefaultSkills().Skills[0].Cost {
+		t.Errorf("jet de sable non appliqué : énergie %d, statuts %v", c.Energy, m.Statuts)
+	}
+}
+
+func TestSkillStopsOnDeadTarget(t *testing.T) {
+	w := NewWorldSeed(1)
+	m := w.Monsters[0]
+	m.Health = 1
+	w.StartCombat(m)
+	d, _ := DefaultSkills().Skill("frappe_chargee")
+
+	// Les dégâts tuent la cible : l'étourdissement n'est pas appliqué
+	if msg, ok := w.useSkill(d); !ok {
+		t.Fatal(msg)
+	}
+	if m.Vivant() || len(m.Statuts) > 0 {
+		t.Errorf("monstre : %d PV, statuts %v", m.Health, m.Statuts)
+	}
+	if strings.Contains(w.CombatMsg.Text, StatusStun.String()) {
+		t.Errorf("effet appliqué à un monstre mort : %q", w.CombatMsg.Text)
+	}
+}
+
+// monsterNamed retourne le premier monstre de la map portant ce nom
+func monsterNamed(t *testing.T, w *World, name string) *Monster {
+	t.Helper()
+	for _, m := range w.Monsters {
+		if m.Name == name {
+			return m
+		}
+	}
+	t.Fatalf("%s absent de la map", name)
+	return nil
+}
+
+func TestCombatTurnOrder(t *testing.T) {
+	w := NewWorldSeed(1)
+	hyena, snake, scorpion := monsterNamed(t, w, "Hyène"), monsterNamed(t, w, "Serpent"), monsterNamed(t, w, "Scorpion")
+
+	// Les plus rapides jouent en premier ; le monstre touché reste visé
+	w.StartCombat(hyena, snake, scorpion)
+	c := w.Combat
+	if want := []*Monster{scorpion, snake, hyena}; !slices.Equal(c.Monsters, want) {
+		t.Errorf("ordre %v, attendu Scorpion, Serpent, Hyène", c.Monsters)
+	}
+	if c.Target() != hyena {
+		t.Errorf("cible %s, attendu Hyène", c.Target().Name)
+	}
+}
+
+func TestCombatEncounter(t *testing.T) {
+	w := NewWorldSeed(1)
+	p := w.Player
+	w.Monsters = w.Monsters[:0]
+	var ms []*Monster
+	for i, d := range []float64{0, 200, 100, 400, 150, 120} {
+		m := &Monster{Name: fmt.Sprint("M", i), W: 10, H: 10, Health: 1, MaxHealth: 1}
+		m.X, m.Y = p.PosX+p.Width/2+d-5, p.PosY+p.Height/2-5
+		ms = append(ms, m)
+		w.Monsters = append(w.Monsters, m)
+	}
+
+	// Le monstre touché, puis les plus proches, sans dépasser maxEnemies
+	got := w.encounter(ms[0])
+	if want := []*Monster{ms[0], ms[2], ms[5], ms[4]}; !slices.Equal(got, want) {
+		var names []string
+		for _, m := range got {
+			names = append(names, m.Name)
+		}
+		t.Errorf("groupe %v, attendu M0 M2 M5 M4", names)
+	}
+}
+
+func TestCombatTargetSkipsDead(t *testing.T) {
+	w := NewWorldSeed(1)
+	hyena, snake, scorpion := monsterNamed(t, w, "Hyène"), monsterNamed(t, w, "Serpent"), monsterNamed(t, w, "Scorpion")
+	w.StartCombat(scorpion, snake, hyena) // Ordre de jeu : scorpion, serpent, hyène
+	c := w.Combat
+
+	snake.Health = 0
+	if c.SelectTarget(1) || c.TargetIndex != 0 {
+		t.Errorf("monstre vaincu visé (cible %d)", c.TargetIndex)
+	}
+	if next, prev := c.NextTarget(1), c.NextTarget(-1); next != 2 || prev != 2 {
+		t.Errorf("cibles suivante %d et précédente %d, attendu 2", next, prev)
+	}
+
+	// La cible tombe : le joueur vise le monstre vivant suivant
+	scorpion.Health = 1
+	w.Update(Input{Punch: true})
+	if scorpion.Vivant() || w.Combat == nil {
+		t.Fatalf("scorpion %d PV, combat %v", scorpion.Health, w.Combat)
+	}
+	if c.Target() != hyena {
+		t.Errorf("cible %s après la mort du scorpion, attendu Hyène", c.Target().Name)
+	}
+}
+
+func TestCombatGroupLoot(t *testing.T) {
+	const seed = 8
+	w := NewWorldSeed(seed)
+	p := w.Player
+	snake, scorpion := monsterNamed(t, w, "Serpent"), monsterNamed(t, w, "Scorpion")
+	hyena := monsterNamed(t, w, "Hyène")
+	money := p.Money
+
+	// Butin attendu : un tirage par monstre vaincu, dans l'ordre de jeu
+	r := NewWorldSeed(seed).RNG.Loot()
+	var gold, xp int
+	var items []string
+	for _, m := range []*Monster{scorpion, snake} {
+		def, _ := DefaultBestiary().Def(m.Name)
+		loot := def.Loot.Roll(r)
+		gold += loot.Gold
+		xp += def.XP
+		items = append(items, loot.Items...)
+	}
+
+	w.StartCombat(snake, scorpion, hyena)
+	snake.Health, scorpion.Health = 0, 0
+	w.Combat.TargetIndex = 2
+	w.EndCombat() // Fuite après deux victoires : la hyène reste
+	v := w.Victory
+	if v == nil || !slices.Equal(v.Monsters, []string{"Scorpion", "Serpent"}) {
+		t.Fatalf("victoire %+v", v)
+	}
+	if v.Gold != gold || p.Money != money+gold || v.XP != xp || !slices.Equal(v.Items, items) {
+		t.Errorf("butin %+v, attendu %d pièces, %d XP, objets %v", v, gold, xp, items)
+	}
+	if slices.Contains(w.Monsters, snake) || slices.Contains(w.Monsters, scorpion) || !slices.Contains(w.Monsters, hyena) {
+		t.Error("seuls les monstres vaincus quittent la map")
+	}
+}
+
+func TestCombatItems(t *testing.T) {
+	w := NewWorldSeed(1)
+	p := w.Player
+	p.Inventory = nil
+	w.StartCombat(w.Monsters[0])
+	c := w.Combat
+
+	// Sans consommable, le menu ne s'ouvre pas
+	w.Update(Input{CombatItems: true})
+	if c.ItemsOpen || !strings.Contains(w.CombatMsg.Text, "Aucun objet") {
+		t.Fatalf("menu ouvert sans objet : %q", w.CombatMsg.Text)
+	}
+
+	p.Inventory = []ItemStack{{ID: "epee", Count: 1}, {ID: "plante_curative", Count: 2}}
+	p.Life = 30
+	w.Update(Input{CombatItems: true})
+	if !c.ItemsOpen {
+		t.Fatal("menu des objets fermé")
+	}
+	// Une arme ne se consomme pas : le tour continue
+	w.Update(Input{UseItem: true, ItemSlot: 0})
+	if !c.PlayerTurn || p.CompterItem("epee") != 1 {
+		t.Fatalf("épée utilisée : %q", w.CombatMsg.Text)
+	}
+
+	// La plante soigne, disparaît de l'inventaire et termine le tour
+	w.Update(Input{UseItem: true, ItemSlot: 1})
+	if p.Life != 80 || p.CompterItem("plante_curative") != 1 {
+		t.Errorf("vie %d, %d plantes après usage", p.Life, p.CompterItem("plante_curative"))
+	}
+	if c.PlayerTurn || c.ItemsOpen {
+		t.Errorf("tour du joueur %v, menu ouvert %v après usage", c.PlayerTurn, c.ItemsOpen)
+	}
+}
@@ -0,0 +1,77 @@
+package source
+
+// ----------------- Combattants -----------------
+// Le joueur et les monstres participent aux combats de la même façon : les
+// coups, les soins et les effets de statut passent par l'interface
+// Combatant. Un monstre garde ses blessures après le combat : s'il est
+// retrouvé après une fuite, il a toujours la vie qu'on lui a laissée.
+
+// Combatant est un participant à un combat
+type Combatant interface {
+	CombatName() string           // Nom affiché
+	Vie() (life, max int)         // Points de vie actuels et maximum
+	Vivant() bool                 // Encore en état de se battre
+	Defender() Defender           // Défenses face à un coup
+	SubirDegats(res DamageResult) // Applique un coup résolu par ResolveDamage
+	Soigner(amount int)           // Rend des points de vie
+
+	statuts() *StatusList    // Effets de statut actifs
+	degatsStatut(dmg int)    // Dégâts d'un effet (ignorent le shield)
+	modifierStats(Modifiers) // Ajoute (ou retire) le bonus d'un effet
+}
+
+// Modifiers est un bonus donné par un effet de statut, ajouté quand l'effet
+// commence et retiré quand il se termine
+type Modifiers struct {
+	Strength int // Force en plus (dégâts en plus pour un monstre)
+	Absorb   int // Dégâts en plus absorbés par chaque point de shield
+}
+
+// add ajoute un bonus à un autre
+func (m *Modifiers) add(o Modifiers) {
+	m.Strength += o.Strength
+	m.Absorb += o.Absorb
+}
+
+// ----------------- Joueur -----------------
+
+func (p *Personnage) CombatName() string   { return p.Name }
+func (p *Personnage) Vie() (life, max int) { return p.Life, p.MaxLife }
+func (p *Personnage) Vivant() bool         { return p.Life > 0 }
+func (p *Personnage) statuts() *StatusList { return &p.Statuts }
+func (p *Personnage) degatsStatut(dmg int) { p.Life = max(0, p.Life-dmg) }
+
+// modifierStats ajoute le bonus d'un effet et recalcule les statistiques
+func (p *Personnage) modifierStats(b Modifiers) {
+	p.Bonus.add(b)
+	p.RecalculerStats()
+}
+
+// ----------------- Monstres -----------------
+
+func (m *Monster) CombatName() string        { return m.Name }
+func (m *Monster) Vie() (life, max int)      { return m.Health, m.MaxHealth }
+func (m *Monster) Vivant() bool              { return m.Health > 0 }
+func (m *Monster) statuts() *StatusList      { return &m.Statuts }
+func (m *Monster) modifierStats(b Modifiers) { m.Bonus.add(b) }
+func (m *Monster) degatsStatut(dmg int)      { m.Health = max(0, m.Health-dmg) }
+
+// Strike retourne le coup porté par le monstre : la rage s'ajoute à ses dégâts
+func (m *Monster) Strike() Strike {
+	return Strike{Attacker: m.Name, Name: "Attaque", Damage: m.Damage + m.Bonus.Strength, CritChance: monsterCritChance, CritMult: critMultiplier}
+}
+
+// Defender retourne les défenses du monstre
+func (m *Monster) Defender() Defender {
+	return Defender{Name: m.Name, Defense: m.Defense, Resistance: m.Resistance, Health: m.Health}
+}
+
+// SubirDegats applique un résultat de ResolveDamage au monstre
+func (m *Monster) SubirDegats(res DamageResult) {
+	m.Health = max(0, m.Health-res.Dealt)
+}
+
+// Soigner rend des points de vie au monstre, sans dépasser son maximum
+func (m *Monster) Soigner(amount int) {
+	m.Health = min(m.Health+amount, m.MaxHealth)
+}
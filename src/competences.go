@@ -0,0 +1,217 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ----------------- Compétences -----------------
+// Les compétences du héros sont décrites dans data/competences.json : coût
+// en énergie, temps de recharge en tours, cible et effets. Une compétence
+// se débloque en atteignant un niveau ou en possédant un objet (porté ou
+// dans l'inventaire). L'énergie et les recharges ne durent qu'un combat.
+
+// SkillTarget est la cible d'une compétence
+type SkillTarget string
+
+const (
+	TargetEnemy SkillTarget = "enemy" // Le monstre affronté
+	TargetSelf  SkillTarget = "self"  // Le joueur
+)
+
+// SkillEffectType est le type d'effet d'une compétence
+type SkillEffectType string
+
+const (
+	SkillDamage SkillEffectType = "damage" // Dégâts (augmentés par la force)
+	SkillBlind  SkillEffectType = "blind"  // L'ennemi rate ses N prochaines attaques
+	SkillStun   SkillEffectType = "stun"   // L'ennemi passe ses N prochains tours
+	SkillStance SkillEffectType = "stance" // Le shield absorbe le double pendant N tours
+	SkillRage   SkillEffectType = "rage"   // Force augmentée pendant N tours
+)
+
+var skillEffectTargets = map[SkillEffectType]SkillTarget{
+	SkillDamage: TargetEnemy,
+	SkillBlind:  TargetEnemy,
+	SkillStun:   TargetEnemy,
+	SkillStance: TargetSelf,
+	SkillRage:   TargetSelf,
+}
+
+// rageStrength est le bonus de force de la rage
+const rageStrength = 5
+
+// Énergie du joueur en combat
+const (
+	combatEnergy    = 2 // Énergie au début d'un combat
+	combatMaxEnergy = 3 // Énergie maximum
+)
+
+// SkillEffect est un effet de compétence
+type SkillEffect struct {
+	Type   SkillEffectType `json:"type"`
+	Amount int             `json:"amount"`
+}
+
+// SkillDef décrit une compétence
+type SkillDef struct {
+	ID          string        `json:"id"`          // Identifiant (unique)
+	Name        string        `json:"name"`        // Nom affiché
+	Description string        `json:"description"` // Description affichée
+	Cost        int           `json:"cost"`        // Énergie dépensée
+	Cooldown    int           `json:"cooldown"`    // Tours avant de pouvoir la réutiliser
+	Target      SkillTarget   `json:"target"`      // Cible
+	Level       int           `json:"level"`       // Niveau nécessaire (0 : aucun)
+	Item        string        `json:"item"`        // Objet nécessaire ("" : aucun)
+	Effects     []SkillEffect `json:"effects"`     // Effets
+}
+
+// SkillRegistry regroupe les compétences du jeu
+type SkillRegistry struct {
+	Skills []SkillDef `json:"skills"`
+
+	byID map[string]*SkillDef
+}
+
+// DefaultSkills retourne les compétences du jeu (data/competences.json).
+func DefaultSkills() *SkillRegistry {
+	return skillsData.get()
+}
+
+// LoadSkills lit et valide un fichier de compétences
+func LoadSkills(path string) (*SkillRegistry, error) {
+	return loadData[SkillRegistry](path)
+}
+
+// ParseSkills décode et valide un fichier de compétences
+func ParseSkills(name string, data []byte) (*SkillRegistry, error) {
+	return parseData[SkillRegistry](name, data)
+}
+
+// validate vérifie chaque compétence
+func (r *SkillRegistry) validate() error {
+	r.byID = map[string]*SkillDef{}
+	var errs []error
+	for i := range r.Skills {
+		d := &r.Skills[i]
+		if err := d.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("compétence %d (%q) : %w", i+1, d.ID, err))
+			continue
+		}
+		if _, ok := r.byID[d.ID]; ok {
+			errs = append(errs, fmt.Errorf("compétence %q définie deux fois", d.ID))
+			continue
+		}
+		r.byID[d.ID] = d
+	}
+	return errors.Join(errs...)
+}
+
+// validate vérifie les valeurs d'une compétence
+func (d *SkillDef) validate() error {
+	switch {
+	case d.ID == "":
+		return errors.New("identifiant manquant")
+	case strings.ContainsAny(d.ID, " \t"):
+		return errors.New("l'identifiant ne doit pas contenir d'espace")
+	case d.Name == "":
+		return errors.New("nom manquant")
+	case d.Cost < 0 || d.Cost > combatMaxEnergy:
+		return fmt.Errorf("coût invalide : %d (maximum %d)", d.Cost, combatMaxEnergy)
+	case d.Cooldown < 0:
+		return errors.New("recharge négative")
+	case d.Target != TargetEnemy && d.Target != TargetSelf:
+		return fmt.Errorf("cible inconnue %q", d.Target)
+	case d.Level < 0:
+		return errors.New("niveau négatif")
+	case d.Level == 0 && d.Item == "":
+		return errors.New(`"level" ou "item" nécessaire pour débloquer la compétence`)
+	case len(d.Effects) == 0:
+		return errors.New("aucun effet")
+	}
+	if d.Item != "" {
+		if _, ok := DefaultItems().Item(d.Item); !ok {
+			return fmt.Errorf("%w %q", ErrUnknownItem, d.Item)
+		}
+	}
+	for _, e := range d.Effects {
+		target, ok := skillEffectTargets[e.Type]
+		if !ok {
+			return fmt.Errorf("effet inconnu %q", e.Type)
+		}
+		if target != d.Target {
+			return fmt.Errorf("l'effet %q ne vise pas %q", e.Type, d.Target)
+		}
+		if e.Amount <= 0 {
+			return fmt.Errorf("valeur de l'effet %q négative ou nulle", e.Type)
+		}
+	}
+	return nil
+}
+
+// Skill retourne la compétence portant cet identifiant
+func (r *SkillRegistry) Skill(id string) (*SkillDef, bool) {
+	d, ok := r.byID[id]
+	return d, ok
+}
+
+// Debloquee indique si le joueur peut utiliser la compétence
+func (d *SkillDef) Debloquee(p *Personnage) bool {
+	if d.Level > 0 && p.Level < d.Level {
+		return false
+	}
+	if d.Item != "" && p.CompterItem(d.Item) == 0 && !p.Porte(d.Item) {
+		return false
+	}
+	return true
+}
+
+// Competences retourne les compétences débloquées, dans l'ordre du fichier
+func (p *Personnage) Competences() []*SkillDef {
+	var skills []*SkillDef
+	for i := range DefaultSkills().Skills {
+		if d := &DefaultSkills().Skills[i]; d.Debloquee(p) {
+			skills = append(skills, d)
+		}
+	}
+	return skills
+}
+
+// useSkill applique une compétence pendant le tour du joueur et l'écrit
+// dans le message de combat. Retourne false (avec le message à afficher)
+// si elle ne peut pas être utilisée.
+func (w *World) useSkill(d *SkillDef) (string, bool) {
+	c := w.Combat
+	p := w.Player
+	if turns := c.Cooldowns[d.ID]; turns > 0 {
+		return fmt.Sprintf("%s : encore %d tour(s) de recharge", d.Name, turns), false
+	}
+	if c.Energy < d.Cost {
+		return fmt.Sprintf("Pas assez d'énergie pour %s !", d.Name), false
+	}
+	c.Energy -= d.Cost
+	if d.Cooldown > 0 {
+		c.Cooldowns[d.ID] = d.Cooldown
+	}
+
+	w.CombatMsg = w.say(p.Name + " utilise " + d.Name + " !")
+	for _, e := range d.Effects {
+		if skillEffectTargets[e.Type] == TargetEnemy && !c.Target().Vivant() {
+			continue // La cible est tombée : plus rien à lui appliquer
+		}
+		switch e.Type {
+		case SkillDamage:
+			w.frapper(p.Strike(d.Name, e.Amount), c.Target())
+		case SkillBlind:
+			w.combatLog(AppliquerStatut(c.Target(), Status{Type: StatusBlind, Turns: e.Amount}))
+		case SkillStun:
+			w.combatLog(AppliquerStatut(c.Target(), Status{Type: StatusStun, Turns: e.Amount}))
+		case SkillStance:
+			w.combatLog(AppliquerStatut(p, Status{Type: StatusGuard, Turns: e.Amount}))
+		case SkillRage:
+			w.combatLog(AppliquerStatut(p, Status{Type: StatusRage, Turns: e.Amount, Power: rageStrength}))
+		}
+	}
+	return "", true
+}
@@ -0,0 +1,226 @@
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ----------------- Table des contrôles -----------------
+// Ebiten identifie les touches par leur position physique sur un clavier US.
+// Le fichier de configuration, lui, nomme les touches telles qu'elles sont
+// imprimées sur le clavier du joueur (AZERTY ou QWERTY).
+
+// Bindings associe chaque action à des touches, boutons de souris et de manette
+type Bindings struct {
+	Layout string                          // "azerty" ou "qwerty" (noms affichés)
+	Keys   map[Action][]ebiten.Key         // Touches physiques
+	Mouse  map[Action][]ebiten.MouseButton // Boutons de la souris
+
+	Gamepad         *GamepadLayout            // Disposition des manettes
+	GamepadProfiles map[string]*GamepadLayout // Dispositions propres à une manette (par SDL ID)
+}
+
+// bindingsFile est le format JSON du fichier de contrôles
+type bindingsFile struct {
+	Layout   string                         `json:"layout"`
+	Bindings map[string][]string            `json:"bindings"`
+	Gamepad  map[string][]string            `json:"gamepad"`
+	Gamepads map[string]map[string][]string `json:"gamepads"`
+}
+
+// Correspondance entre les touches AZERTY et leur position QWERTY
+var azertyToPhysical = map[string]string{
+	"A":     "Q",
+	"Q":     "A",
+	"Z":     "W",
+	"W":     "Z",
+	"M":     "Semicolon",
+	"Comma": "M",
+}
+
+// Noms des boutons de souris utilisables dans le fichier
+var mouseButtonNames = map[string]ebiten.MouseButton{
+	"MouseLeft":   ebiten.MouseButtonLeft,
+	"MouseRight":  ebiten.MouseButtonRight,
+	"MouseMiddle": ebiten.MouseButtonMiddle,
+}
+
+// DefaultBindings retourne les contrôles par défaut (ZQSD en AZERTY, WASD en QWERTY)
+func DefaultBindings() *Bindings {
+	return &Bindings{
+		Layout: "azerty",
+		Keys: map[Action][]ebiten.Key{
+			ActionMoveUp:          {ebiten.KeyW},
+			ActionMoveDown:        {ebiten.KeyS},
+			ActionMoveLeft:        {ebiten.KeyA},
+			ActionMoveRight:       {ebiten.KeyD},
+			ActionAttack:          {ebiten.KeyQ},
+			ActionUseSword:        {ebiten.KeyE},
+			ActionCombatItems:     {ebiten.KeyB},
+			ActionSkill1:          {ebiten.Key1},
+			ActionSkill2:          {ebiten.Key2},
+			ActionSkill3:          {ebiten.Key3},
+			ActionSkill4:          {ebiten.Key4},
+			ActionFlee:            {ebiten.KeySpace},
+			ActionToggleInventory: {ebiten.KeyP},
+			ActionZoomIn:          {ebiten.KeyKPAdd, ebiten.KeyEqual},
+			ActionZoomOut:         {ebiten.KeyKPSubtract, ebiten.KeyMinus},
+			ActionCameraUp:        {ebiten.KeyArrowUp},
+			ActionCameraDown:      {ebiten.KeyArrowDown},
+			ActionCameraLeft:      {ebiten.KeyArrowLeft},
+			ActionCameraRight:     {ebiten.KeyArrowRight},
+			ActionPause:           {ebiten.KeyEscape},
+			ActionNavUp:           {ebiten.KeyArrowUp},
+			ActionNavDown:         {ebiten.KeyArrowDown},
+			ActionNavLeft:         {ebiten.KeyArrowLeft},
+			ActionNavRight:        {ebiten.KeyArrowRight},
+			ActionConfirm:         {ebiten.KeyEnter},
+		},
+		Mouse: map[Action][]ebiten.MouseButton{
+			ActionClick: {ebiten.MouseButtonLeft},
+		},
+		Gamepad:         DefaultGamepadLayout(),
+		GamepadProfiles: map[string]*GamepadLayout{},
+	}
+}
+
+// configDir retourne le dossier de configuration du jeu
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sahara-defender"), nil
+}
+
+// LoadBindings charge les contrôles du joueur depuis controles.json
+// dans le dossier de configuration. Sans fichier, les contrôles par défaut sont utilisés.
+func LoadBindings() (*Bindings, error) {
+	dir, err := configDir()
+	if err != nil {
+		return DefaultBindings(), err
+	}
+	return LoadBindingsFile(filepath.Join(dir, "controles.json"))
+}
+
+// LoadBindingsFile charge un fichier de contrôles. Les actions absentes du
+// fichier gardent leurs touches par défaut.
+func LoadBindingsFile(path string) (*Bindings, error) {
+	b := DefaultBindings()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return b, err
+	}
+
+	var f bindingsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return DefaultBindings(), fmt.Errorf("%s : %w", path, err)
+	}
+	if err := b.apply(f); err != nil {
+		return DefaultBindings(), fmt.Errorf("%s : %w", path, err)
+	}
+	return b, nil
+}
+
+// apply remplace les touches des actions présentes dans le fichier
+func (b *Bindings) apply(f bindingsFile) error {
+	switch f.Layout {
+	case "", "azerty", "qwerty":
+		if f.Layout != "" {
+			b.Layout = f.Layout
+		}
+	default:
+		return fmt.Errorf("disposition de clavier inconnue : %q", f.Layout)
+	}
+
+	for name, inputs := range f.Bindings {
+		a, err := ParseAction(name)
+		if err != nil {
+			return err
+		}
+		var keys []ebiten.Key
+		var buttons []ebiten.MouseButton
+		for _, in := range inputs {
+			if mb, ok := mouseButtonNames[in]; ok {
+				buttons = append(buttons, mb)
+				continue
+			}
+			k, err := b.parseKey(in)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, k)
+		}
+		b.Keys[a] = keys
+		b.Mouse[a] = buttons
+	}
+
+	if err := b.Gamepad.apply(f.Gamepad); err != nil {
+		return err
+	}
+	for sdlID, bindings := range f.Gamepads {
+		l := b.Gamepad.clone()
+		if err := l.apply(bindings); err != nil {
+			return fmt.Errorf("manette %s : %w", sdlID, err)
+		}
+		b.GamepadProfiles[sdlID] = l
+	}
+	return nil
+}
+
+// parseKey convertit un nom de touche imprimé sur le clavier en touche physique
+func (b *Bindings) parseKey(name string) (ebiten.Key, error) {
+	if b.Layout == "azerty" {
+		if physical, ok := azertyToPhysical[name]; ok {
+			name = physical
+		}
+	}
+	var k ebiten.Key
+	if err := k.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("touche inconnue : %q", name)
+	}
+	return k, nil
+}
+
+// Pressed indique si une des touches de l'action est enfoncée
+func (b *Bindings) Pressed(a Action) bool {
+	for _, k := range b.Keys[a] {
+		if ebiten.IsKeyPressed(k) {
+			return true
+		}
+	}
+	for _, mb := range b.Mouse[a] {
+		if ebiten.IsMouseButtonPressed(mb) {
+			return true
+		}
+	}
+	return false
+}
+
+// Label retourne le nom de la première touche de l'action, tel qu'imprimé sur le clavier
+func (b *Bindings) Label(a Action) string {
+	keys := b.Keys[a]
+	if len(keys) == 0 {
+		return "?"
+	}
+	name := keys[0].String()
+	if b.Layout == "azerty" {
+		for label, physical := range azertyToPhysical {
+			if physical == name {
+				return label
+			}
+		}
+	}
+	return strings.TrimPrefix(name, "Digit") // Chiffres : "1" plutôt que "Digit1"
+}
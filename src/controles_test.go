@@ -0,0 +1,115 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// writeBindings écrit un fichier de contrôles temporaire
+func writeBindings(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "controles.json")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadBindingsFile(t *testing.T) {
+	b, err := LoadBindingsFile(filepath.Join(t.TempDir(), "absent.json"))
+	if err != nil || !reflect.DeepEqual(b, DefaultBindings()) {
+		t.Errorf("sans fichier : erreur %v, contrôles par défaut %v", err, reflect.DeepEqual(b, DefaultBindings()))
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want map[Action][]ebiten.Key
+	}{
+		// Touches nommées comme sur un clavier AZERTY
+		{"azerty", `{"bindings": {"Attack": ["A"], "MoveUp": ["Z", "ArrowUp"]}}`, map[Action][]ebiten.Key{
+			ActionAttack:   {ebiten.KeyQ},
+			ActionMoveUp:   {ebiten.KeyW, ebiten.KeyArrowUp},
+			ActionMoveDown: {ebiten.KeyS}, // Absente du fichier : touche par défaut
+		}},
+		{"qwerty", `{"layout": "qwerty", "bindings": {"Attack": ["A"]}}`, map[Action][]ebiten.Key{
+			ActionAttack: {ebiten.KeyA},
+		}},
+		// Ancien nom d'action encore accepté
+		{"alias", `{"bindings": {"DrinkHealPotion": ["H"]}}`, map[Action][]ebiten.Key{
+			ActionCombatItems: {ebiten.KeyH},
+		}},
+	}
+	for _, tt := range tests {
+		b, err := LoadBindingsFile(writeBindings(t, tt.data))
+		if err != nil {
+			t.Errorf("%s : %v", tt.name, err)
+			continue
+		}
+		for a, keys := range tt.want {
+			if !slices.Equal(b.Keys[a], keys) {
+				t.Errorf("%s : %s sur %v, attendu %v", tt.name, a, b.Keys[a], keys)
+			}
+		}
+	}
+
+	b, err = LoadBindingsFile(writeBindings(t, `{"bindings": {"Attack": ["MouseRight"]}}`))
+	if err != nil || len(b.Keys[ActionAttack]) != 0 || !slices.Equal(b.Mouse[ActionAttack], []ebiten.MouseButton{ebiten.MouseButtonRight}) {
+		t.Errorf("souris : touches %v, boutons %v (%v)", b.Keys[ActionAttack], b.Mouse[ActionAttack], err)
+	}
+	if got := DefaultBindings().Label(ActionAttack); got != "A" {
+		t.Errorf("étiquette de l'attaque %q, attendu \"A\"", got)
+	}
+}
+
+func TestLoadBindingsFileErrors(t *testing.T) {
+	// Un fichier invalide n'est jamais appliqué à moitié
+	for _, data := range []string{
+		`{"bindings": {"Attack": ["A"]`,
+		`{"bindings": {"Attack": ["A"], "Danser": ["D"]}}`,
+		`{"bindings": {"Attack": ["A"], "Flee": ["Espace"]}}`,
+		`{"layout": "dvorak", "bindings": {"Attack": ["A"]}}`,
+	} {
+		b, err := LoadBindingsFile(writeBindings(t, data))
+		if err == nil {
+			t.Errorf("%s : fichier accepté", data)
+		}
+		if !reflect.DeepEqual(b, DefaultBindings()) {
+			t.Errorf("%s : contrôles par défaut non rétablis", data)
+		}
+	}
+}
+
+func TestActionStateEdges(t *testing.T) {
+	var s ActionState
+	ticks := []struct {
+		held                   bool
+		pressed, released, hit bool
+	}{
+		{false, false, false, false},
+		{true, true, false, true}, // Appui : une seule frappe
+		{true, false, false, false},
+		{true, false, false, false},
+		{false, false, true, false}, // Relâchement
+		{false, false, false, false},
+		{true, true, false, true},
+	}
+	for i, tt := range ticks {
+		s.Update(func(a Action) bool {
+			return a == ActionMoveUp || (a == ActionAttack && tt.held)
+		})
+		if s.JustPressed(ActionAttack) != tt.pressed || s.JustReleased(ActionAttack) != tt.released || s.Held(ActionAttack) != tt.held {
+			t.Errorf("tick %d : appui %v, relâchement %v, maintenue %v", i, s.JustPressed(ActionAttack), s.JustReleased(ActionAttack), s.Held(ActionAttack))
+		}
+		// Les déplacements suivent la touche maintenue, les frappes l'appui
+		in := s.Input()
+		if in.Punch != tt.hit || !in.Up {
+			t.Errorf("tick %d : frappe %v, déplacement %v", i, in.Punch, in.Up)
+		}
+	}
+}
@@ -1,11 +1,10 @@
 package source
 
-// Structure d'une entité (joueur ou monstre)
-type Entity struct {
-	Name   string
-	Health int
-	Damage int
-}
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
 
 // Structure d'une arme
 type Weapon struct {
@@ -13,17 +12,113 @@ type Weapon struct {
 	Damage int
 }
 
-// Inflige des dégâts à l'entité
-func (e *Entity) TakeDamage(damage int) {
-	e.Health -= damage
-	if e.Health < 0 {
-		e.Health = 0
+// ----------------- Calcul des dégâts -----------------
+// Tous les coups (joueur, monstres, compétences) passent par ResolveDamage :
+// dégâts de base, écart aléatoire, coup critique, puis défense et
+// résistance de la cible et enfin absorption par le shield. Le résultat
+// détaillé sert aux messages de combat.
+
+// Réglages du calcul des dégâts
+const (
+	damageSpread      = 10   // Écart aléatoire en % (±)
+	critMultiplier    = 1.5  // Multiplicateur des coups critiques
+	playerCritChance  = 0.10 // Chance de critique du joueur
+	monsterCritChance = 0.05 // Chance de critique des monstres
+	maxResistance     = 0.9  // Résistance maximum
+)
+
+// Strike décrit un coup porté
+type Strike struct {
+	Attacker   string  // Nom de l'attaquant
+	Name       string  // Nom du coup (arme, compétence...)
+	Damage     int     // Dégâts de base (force déjà appliquée)
+	CritChance float64 // Chance de coup critique (0 à 1)
+	CritMult   float64 // Multiplicateur en cas de critique
+}
+
+// Defender décrit la cible d'un coup
+type Defender struct {
+	Name       string  // Nom de la cible
+	Defense    int     // Dégâts retirés
+	Resistance float64 // Part des dégâts ignorée
+	Shield     int     // Shield restant
+	Absorb     int     // Dégâts absorbés par point de shield
+	Health     int     // Vie restante
+}
+
+// DamageResult détaille les dégâts d'un coup
+type DamageResult struct {
+	Attacker   string // Nom de l'attaquant ("" : effet sans attaquant)
+	Target     string // Nom de la cible
+	Raw        int    // Dégâts avant défense (écart et critique compris)
+	Mitigated  int    // Retirés par la défense et la résistance
+	Absorbed   int    // Absorbés par le shield
+	ShieldLost int    // Points de shield perdus
+	Dealt      int    // Vie perdue
+	Crit       bool   // Coup critique
+	Killed     bool   // La cible n'a plus de vie
+}
+
+// ResolveDamage calcule les dégâts d'un coup sans modifier la cible
+func ResolveDamage(r *rand.Rand, s Strike, d Defender) DamageResult {
+	raw := s.Damage * (100 - damageSpread + r.IntN(2*damageSpread+1)) / 100
+	crit := s.CritChance > 0 && r.Float64() < s.CritChance
+	if crit {
+		raw = int(float64(raw) * s.CritMult)
 	}
+	raw = max(raw, 1)
+
+	dmg := max(raw-d.Defense, 0)
+	dmg = int(float64(dmg) * (1 - min(d.Resistance, maxResistance)))
+	dmg = max(dmg, 1) // Un coup fait toujours au moins 1 dégât
+
+	res := DamageResult{Attacker: s.Attacker, Target: d.Name, Raw: raw, Mitigated: raw - dmg, Crit: crit}
+	return absorbDamage(res, dmg, d)
+}
+
+// absorbDamage répartit les dégâts entre le shield et la vie
+func absorbDamage(res DamageResult, dmg int, d Defender) DamageResult {
+	absorb := max(d.Absorb, 1)
+	res.Absorbed = min(dmg, d.Shield*absorb)
+	res.ShieldLost = (res.Absorbed + absorb - 1) / absorb
+	res.Dealt = min(dmg-res.Absorbed, d.Health)
+	res.Killed = d.Health-res.Dealt <= 0
+	return res
 }
 
-// Fonction d'attaque entre deux entités
-func Attack(attacker *Entity, defender *Entity, weapon Weapon) {
-	if defender.Health > 0 {
-		defender.TakeDamage(weapon.Damage)
+// String retourne le message de combat décrivant le coup
+func (res DamageResult) String() string {
+	var b strings.Builder
+	if res.Attacker != "" {
+		fmt.Fprintf(&b, "%s inflige %d dégâts à %s", res.Attacker, res.Raw-res.Mitigated, res.Target)
+	} else {
+		fmt.Fprintf(&b, "%s subit %d dégâts", res.Target, res.Raw-res.Mitigated)
+	}
+	if res.Crit {
+		b.WriteString(" (critique !)")
+	}
+	var parts []string
+	if res.Mitigated > 0 {
+		parts = append(parts, fmt.Sprintf("parés %d", res.Mitigated))
+	}
+	if res.ShieldLost > 0 {
+		parts = append(parts, fmt.Sprintf("Shield -%d", res.ShieldLost))
 	}
+	if res.Dealt > 0 {
+		parts = append(parts, fmt.Sprintf("Vie -%d", res.Dealt))
+	}
+	if len(parts) > 0 {
+		b.WriteString(" : " + strings.Join(parts, ", "))
+	}
+	if res.Killed {
+		fmt.Fprintf(&b, ". %s est vaincu !", res.Target)
+	}
+	return b.String()
+}
+
+// Attack résout un coup et l'applique à sa cible
+func Attack(r *rand.Rand, s Strike, target Combatant) DamageResult {
+	res := ResolveDamage(r, s, target.Defender())
+	target.SubirDegats(res)
+	return res
 }
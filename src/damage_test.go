@@ -0,0 +1,130 @@
+package source
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// testRand retourne un flux aléatoire à graine fixe
+func testRand() *rand.Rand {
+	return rand.New(rand.NewPCG(42, 1))
+}
+
+func TestResolveDamageSpread(t *testing.T) {
+	r := testRand()
+	s := Strike{Attacker: "A", Name: "Coup", Damage: 100}
+	low, high := 1000, 0
+	for i := 0; i < 2000; i++ {
+		res := ResolveDamage(r, s, Defender{Name: "B", Health: 1000})
+		if res.Crit {
+			t.Fatal("critique sans chance de critique")
+		}
+		if res.Mitigated != 0 || res.Dealt != res.Raw {
+			t.Fatalf("dégâts réduits sans défense : %+v", res)
+		}
+		low, high = min(low, res.Raw), max(high, res.Raw)
+	}
+	if low != 100-damageSpread || high != 100+damageSpread {
+		t.Errorf("écart de %d à %d, attendu de %d à %d", low, high, 100-damageSpread, 100+damageSpread)
+	}
+}
+
+func TestResolveDamageCrit(t *testing.T) {
+	r := testRand()
+	s := Strike{Damage: 100, CritChance: 1, CritMult: critMultiplier}
+	for i := 0; i < 200; i++ {
+		res := ResolveDamage(r, s, Defender{Health: 1000})
+		if !res.Crit || res.Raw < 135 || res.Raw > 165 {
+			t.Fatalf("critique attendu entre 135 et 165 : %+v", res)
+		}
+	}
+
+	// Chance de critique du joueur : environ 10 % des coups
+	s.CritChance = playerCritChance
+	crits := 0
+	for i := 0; i < 10000; i++ {
+		if ResolveDamage(r, s, Defender{Health: 1000}).Crit {
+			crits++
+		}
+	}
+	if crits < 800 || crits > 1200 {
+		t.Errorf("%d critiques sur 10000 coups à %v", crits, playerCritChance)
+	}
+}
+
+func TestResolveDamageMitigation(t *testing.T) {
+	r := testRand()
+	tests := []struct {
+		name       string
+		defense    int
+		resistance float64
+		resist     float64 // Résistance réellement appliquée
+	}{
+		{"défense", 20, 0, 0},
+		{"résistance", 0, 0.5, 0.5},
+		{"défense et résistance", 10, 0.25, 0.25},
+		{"résistance plafonnée", 0, 2, maxResistance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				res := ResolveDamage(r, Strike{Damage: 100}, Defender{Defense: tt.defense, Resistance: tt.resistance, Health: 1000})
+				want := max(int(float64(res.Raw-tt.defense)*(1-tt.resist)), 1)
+				if res.Dealt != want || res.Mitigated != res.Raw-want {
+					t.Fatalf("brut %d : %d dégâts (parés %d), attendu %d", res.Raw, res.Dealt, res.Mitigated, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveDamageMinimum(t *testing.T) {
+	r := testRand()
+	for _, d := range []Defender{{Defense: 500, Health: 10}, {Resistance: 0.9, Defense: 4, Health: 10}} {
+		res := ResolveDamage(r, Strike{Damage: 5}, d)
+		if res.Dealt != 1 {
+			t.Errorf("%+v : %d dégâts, attendu 1", d, res.Dealt)
+		}
+	}
+	// Un coup de 0 compte aussi pour 1
+	if res := ResolveDamage(r, Strike{Damage: 0}, Defender{Health: 10}); res.Raw != 1 || res.Dealt != 1 {
+		t.Errorf("coup nul : %+v", res)
+	}
+}
+
+func TestAbsorbDamage(t *testing.T) {
+	tests := []struct {
+		name                       string
+		dmg                        int
+		d                          Defender
+		absorbed, shieldLost, life int
+		killed                     bool
+	}{
+		{"sans shield", 7, Defender{Health: 20}, 0, 0, 7, false},
+		{"shield suffisant", 7, Defender{Shield: 10, Absorb: 1, Health: 20}, 7, 7, 0, false},
+		{"shield percé", 7, Defender{Shield: 3, Absorb: 1, Health: 20}, 3, 3, 4, false},
+		{"posture : arrondi au-dessus", 7, Defender{Shield: 10, Absorb: 2, Health: 20}, 7, 4, 0, false},
+		{"posture : shield percé", 7, Defender{Shield: 2, Absorb: 2, Health: 20}, 4, 2, 3, false},
+		{"absorption nulle comptée 1", 5, Defender{Shield: 2, Health: 20}, 2, 2, 3, false},
+		{"coup fatal", 30, Defender{Health: 20}, 0, 0, 20, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := absorbDamage(DamageResult{}, tt.dmg, tt.d)
+			if res.Absorbed != tt.absorbed || res.ShieldLost != tt.shieldLost || res.Dealt != tt.life || res.Killed != tt.killed {
+				t.Errorf("%+v, attendu absorbés %d, shield -%d, vie -%d, vaincu %v", res, tt.absorbed, tt.shieldLost, tt.life, tt.killed)
+			}
+		})
+	}
+}
+
+func TestResolveDamageSeed(t *testing.T) {
+	s := Strike{Damage: 40, CritChance: 0.5, CritMult: critMultiplier}
+	d := Defender{Defense: 3, Resistance: 0.1, Shield: 5, Absorb: 1, Health: 100}
+	a, b := testRand(), testRand()
+	for i := 0; i < 100; i++ {
+		if ra, rb := ResolveDamage(a, s, d), ResolveDamage(b, s, d); ra != rb {
+			t.Fatalf("même graine, coups différents : %+v et %+v", ra, rb)
+		}
+	}
+}
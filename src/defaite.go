@@ -0,0 +1,59 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ----------------- Défaite -----------------
+// Quand le joueur n'a plus de vie, le combat s'arrête et la partie passe en
+// défaite : plus aucun déplacement n'est possible. Le joueur reprend au camp
+// (au prix d'une partie de son or) ou recharge une sauvegarde.
+
+// Réglages de la reprise au camp
+const (
+	campX, campY       = 1240, 600 // Position du camp (départ de la partie)
+	respawnGoldPenalty = 20        // Part de l'or perdue, en %
+	respawnLifeShare   = 50        // Part de la vie maximum rendue, en %
+)
+
+// defeat termine le combat par la mort du joueur
+func (w *World) defeat() {
+	var names []string
+	for _, m := range w.Combat.Living() {
+		names = append(names, m.Name)
+	}
+	by := strings.Join(names, ", ")
+	w.endCombat(false) // Pas de butin après une défaite
+	w.Defeated = true
+	w.CombatMsg = w.say(fmt.Sprintf("%s a été vaincu par %s...", w.Player.Name, by))
+}
+
+// updateDefeat attend que le joueur choisisse de reprendre au camp
+func (w *World) updateDefeat(in Input) {
+	if in.Respawn {
+		w.Respawn()
+	}
+}
+
+// RespawnPenalty retourne l'or perdu en reprenant au camp
+func (w *World) RespawnPenalty() int {
+	return w.Player.Money * respawnGoldPenalty / 100
+}
+
+// Respawn fait reprendre le joueur au camp après une défaite : une partie
+// de sa vie est rendue, une partie de son or est perdue
+func (w *World) Respawn() {
+	if !w.Defeated {
+		return
+	}
+	p := w.Player
+	lost := w.RespawnPenalty()
+	p.Money -= lost
+	p.Life = max(p.MaxLife*respawnLifeShare/100, 1)
+	p.PosX, p.PosY = campX, campY
+	w.PlayerDir = DirDown
+	w.Defeated = false
+	w.SafeUntil = w.Tick + fleeImmunity
+	w.CombatMsg = w.say(fmt.Sprintf("Vous reprenez des forces au camp. (-%d pièces d'or)", lost))
+}
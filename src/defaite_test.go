@@ -0,0 +1,89 @@
+package source
+
+import "testing"
+
+// losingCombat lance un combat perdu d'avance : le joueur n'a qu'1 PV face
+// à une hyène et un scorpion presque mort
+func losingCombat(t *testing.T) (w *World, hyena, scorpion *Monster) {
+	t.Helper()
+	w = NewWorldSeed(3)
+	p := w.Player
+	p.Life, p.Shield = 1, 0
+	for _, m := range w.Monsters {
+		switch m.Name {
+		case "Hyène":
+			hyena = m
+		case "Scorpion":
+			scorpion = m
+		}
+	}
+	if hyena == nil || scorpion == nil {
+		t.Fatal("hyène ou scorpion absent de la map")
+	}
+	hyena.Health = hyena.MaxHealth
+	scorpion.Health = 1
+	w.StartCombat(scorpion, hyena)
+	return w, hyena, scorpion
+}
+
+func TestDefeat(t *testing.T) {
+	w, hyena, scorpion := losingCombat(t)
+	p := w.Player
+	money, xp, level := p.Money, p.XP, p.Level
+
+	// Le scorpion tombe au premier coup, puis la hyène tue le joueur
+	for i := 0; !w.Defeated; i++ {
+		if i > 100 || w.Combat == nil {
+			t.Fatal("le joueur devrait perdre le combat")
+		}
+		w.Update(Input{Punch: w.Combat.PlayerTurn})
+	}
+	if w.Combat != nil || p.Life != 0 {
+		t.Fatalf("combat %v, vie %d", w.Combat, p.Life)
+	}
+	if want := p.Name + " a été vaincu par Hyène..."; w.CombatMsg.Text != want {
+		t.Errorf("message %q, attendu %q", w.CombatMsg.Text, want)
+	}
+
+	// Aucune récompense : ni butin, ni or, ni expérience
+	if w.Victory != nil || p.Money != money || p.XP != xp || p.Level != level {
+		t.Errorf("récompense après une défaite : %+v, or %d, XP %d", w.Victory, p.Money, p.XP)
+	}
+	for _, m := range w.Monsters {
+		if m == scorpion {
+			t.Error("le scorpion vaincu est encore sur la map")
+		}
+	}
+	if hyena.Health == 0 || len(hyena.Statuts) > 0 {
+		t.Errorf("hyène : %d PV, statuts %v", hyena.Health, hyena.Statuts)
+	}
+
+	// À 0 PV, l'exploration ignore les entrées et rien ne bouge
+	x, y := p.PosX, p.PosY
+	hx, hy := hyena.X, hyena.Y
+	for i := 0; i < 30; i++ {
+		w.Update(Input{Right: true, Down: true, ToggleInventory: true})
+	}
+	if p.PosX != x || p.PosY != y || hyena.X != hx || hyena.Y != hy || w.InventoryOpen {
+		t.Error("la partie continue après la défaite")
+	}
+	if _, err := w.Save(); err == nil {
+		t.Error("sauvegarde acceptée après la défaite")
+	}
+
+	// Reprise au camp
+	penalty := w.RespawnPenalty()
+	if penalty != money*respawnGoldPenalty/100 {
+		t.Errorf("pénalité %d", penalty)
+	}
+	w.Update(Input{Respawn: true})
+	if w.Defeated || p.PosX != campX || p.PosY != campY {
+		t.Errorf("reprise : défaite %v, position %v,%v", w.Defeated, p.PosX, p.PosY)
+	}
+	if p.Money != money-penalty || p.Life != p.MaxLife*respawnLifeShare/100 {
+		t.Errorf("reprise : or %d, vie %d/%d", p.Money, p.Life, p.MaxLife)
+	}
+	if !w.Immune() {
+		t.Error("le joueur devrait être protégé juste après la reprise")
+	}
+}
@@ -0,0 +1,265 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// ----------------- Mode Défenseur -----------------
+// Dans ce mode, des vagues de monstres du bestiaire marchent vers le camp.
+// Le joueur les intercepte (combat habituel) ; un monstre qui atteint le
+// camp lui fait perdre de la vie. Entre deux vagues, le marchand s'ouvre.
+// Les vagues sont décrites dans data/defenseur.json : après la dernière,
+// elle est rejouée avec un monstre de plus par groupe à chaque vague. Le
+// score est le nombre de vagues repoussées. Une partie Défenseur ne se
+// sauvegarde pas.
+
+// DefensePhase est l'étape en cours du mode Défenseur
+type DefensePhase int
+
+const (
+	PhaseWaiting DefensePhase = iota // Compte à rebours avant la vague
+	PhaseWave                        // Vague en cours
+	PhaseShop                        // Vague repoussée : marchand ouvert
+	PhaseLost                        // Le camp est tombé
+)
+
+// defenseZone marque les monstres des vagues (champ Monster.Zone)
+const defenseZone = "vague"
+
+// CampDef décrit le camp à défendre
+type CampDef struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Life   int     `json:"life"` // Points de vie du camp
+}
+
+// SpawnPoint est un point d'arrivée des monstres
+type SpawnPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// WaveGroup est un groupe de monstres d'une vague
+type WaveGroup struct {
+	Monster string `json:"monster"` // Nom du type de monstre
+	Count   int    `json:"count"`   // Nombre de monstres
+}
+
+// WaveDef décrit une vague
+type WaveDef struct {
+	Monsters []WaveGroup `json:"monsters"`
+}
+
+// DefenseConfig décrit le mode Défenseur
+type DefenseConfig struct {
+	Camp         CampDef      `json:"camp"`          // Camp à défendre
+	SpawnPoints  []SpawnPoint `json:"spawn_points"`  // Points d'arrivée des monstres
+	Interval     float64      `json:"interval"`      // Secondes entre deux monstres d'une vague
+	Delay        float64      `json:"delay"`         // Secondes avant chaque vague
+	HealthGrowth float64      `json:"health_growth"` // Vie des monstres en plus à chaque vague (0.1 = +10 %)
+	Bonus        int          `json:"bonus"`         // Or gagné par vague repoussée (x numéro de la vague)
+	Waves        []WaveDef    `json:"waves"`         // Vagues, de la première à la dernière
+}
+
+// DefaultDefense retourne la configuration du mode Défenseur
+// (data/defenseur.json).
+func DefaultDefense() *DefenseConfig {
+	return defenseData.get()
+}
+
+// LoadDefense lit et valide un fichier du mode Défenseur
+func LoadDefense(path string) (*DefenseConfig, error) {
+	return loadData[DefenseConfig](path)
+}
+
+// ParseDefense décode et valide un fichier du mode Défenseur
+func ParseDefense(name string, data []byte) (*DefenseConfig, error) {
+	return parseData[DefenseConfig](name, data)
+}
+
+// validate vérifie le camp et chaque vague
+func (c *DefenseConfig) validate() error {
+	switch {
+	case c.Camp.Width <= 0 || c.Camp.Height <= 0:
+		return errors.New("taille du camp négative ou nulle")
+	case c.Camp.Life <= 0:
+		return errors.New("vie du camp négative ou nulle")
+	case len(c.SpawnPoints) == 0:
+		return errors.New("aucun point d'arrivée")
+	case c.Interval < 0 || c.Delay < 0:
+		return errors.New("délai négatif")
+	case c.HealthGrowth < 0:
+		return errors.New("croissance de la vie négative")
+	case c.Bonus < 0:
+		return errors.New("bonus négatif")
+	case len(c.Waves) == 0:
+		return errors.New("aucune vague")
+	}
+	var errs []error
+	for i, wave := range c.Waves {
+		if len(wave.Monsters) == 0 {
+			errs = append(errs, fmt.Errorf("vague %d : aucun monstre", i+1))
+		}
+		for _, g := range wave.Monsters {
+			if _, ok := DefaultBestiary().Def(g.Monster); !ok {
+				errs = append(errs, fmt.Errorf("vague %d : monstre inconnu %q", i+1, g.Monster))
+			}
+			if g.Count <= 0 {
+				errs = append(errs, fmt.Errorf("vague %d : nombre invalide pour %q : %d", i+1, g.Monster, g.Count))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Wave retourne les monstres de la vague n (1 = première), dans l'ordre d'arrivée
+func (c *DefenseConfig) Wave(n int) []string {
+	wave := c.Waves[min(n, len(c.Waves))-1]
+	extra := max(n-len(c.Waves), 0) // Au-delà de la dernière vague : un monstre de plus par groupe
+	var names []string
+	for _, g := range wave.Monsters {
+		for i := 0; i < g.Count+extra; i++ {
+			names = append(names, g.Monster)
+		}
+	}
+	return names
+}
+
+// Defense est l'état d'une partie en mode Défenseur
+type Defense struct {
+	Phase     DefensePhase
+	Wave      int      // Vague en cours ou à venir (1 = première)
+	CampLife  int      // Vie restante du camp
+	Queue     []string // Monstres de la vague pas encore arrivés
+	NextSpawn int      // Tick de la prochaine arrivée (ou du début de la vague)
+	Survived  int      // Vagues repoussées (score)
+}
+
+// Lost indique si le camp est tombé (false hors du mode Défenseur)
+func (d *Defense) Lost() bool {
+	return d != nil && d.Phase == PhaseLost
+}
+
+// NewDefenderWorld crée une partie en mode Défenseur : pas de monstres
+// sur la map, le joueur part du camp
+func NewDefenderWorld(seed uint64) *World {
+	c := DefaultDefense()
+	w := NewWorldSeed(seed)
+	w.Monsters = nil
+	w.Defense = &Defense{
+		Phase:     PhaseWaiting,
+		Wave:      1,
+		CampLife:  c.Camp.Life,
+		NextSpawn: w.Tick + int(c.Delay*TicksPerSecond),
+	}
+	return w
+}
+
+// updateDefense fait avancer les vagues pendant l'exploration
+func (w *World) updateDefense() {
+	c := DefaultDefense()
+	d := w.Defense
+	switch d.Phase {
+	case PhaseWaiting:
+		if w.Tick >= d.NextSpawn {
+			d.Phase = PhaseWave
+			d.Queue = c.Wave(d.Wave)
+			w.CombatMsg = w.say(fmt.Sprintf("Vague %d : %d monstres arrivent !", d.Wave, len(d.Queue)))
+		}
+	case PhaseWave:
+		if len(d.Queue) > 0 && w.Tick >= d.NextSpawn {
+			w.spawnAttacker(d.Queue[0])
+			d.Queue = d.Queue[1:]
+			d.NextSpawn = w.Tick + int(c.Interval*TicksPerSecond)
+		}
+		w.checkCamp()
+		if d.Phase == PhaseWave && len(d.Queue) == 0 && w.population(defenseZone) == 0 {
+			bonus := c.Bonus * d.Wave
+			w.Player.Money += bonus
+			d.Survived++
+			d.Wave++
+			d.Phase = PhaseShop
+			w.ShopOpen = true
+			w.ShopMsg = w.say(fmt.Sprintf("Vague %d repoussée ! +%d pièces d'or. Fermez le marchand pour la suite.", d.Survived, bonus))
+		}
+	case PhaseShop:
+		if !w.ShopOpen {
+			d.Phase = PhaseWaiting
+			d.NextSpawn = w.Tick + int(c.Delay*TicksPerSecond)
+		}
+	}
+}
+
+// spawnAttacker fait arriver un monstre de la vague sur un point d'arrivée
+func (w *World) spawnAttacker(name string) {
+	c := DefaultDefense()
+	def, ok := DefaultBestiary().Def(name)
+	if !ok {
+		return
+	}
+	r := w.RNG.World()
+	sp := c.SpawnPoints[r.IntN(len(c.SpawnPoints))]
+	m := def.NewMonster(sp.X, sp.Y)
+	m.Health = int(float64(def.Health) * (1 + c.HealthGrowth*float64(w.Defense.Wave-1)))
+	m.MaxHealth = m.Health
+	m.Behaviour = BehaviourMarch
+	m.Zone = defenseZone
+	// Le camp devient le point de départ : le monstre y retourne après une poursuite
+	m.HomeX = c.Camp.X + c.Camp.Width/2 - m.W/2
+	m.HomeY = c.Camp.Y + c.Camp.Height/2 - m.H/2
+	w.Monsters = append(w.Monsters, m)
+}
+
+// checkCamp retire les monstres arrivés au camp et lui fait perdre de la vie
+func (w *World) checkCamp() {
+	camp := DefaultDefense().Camp
+	d := w.Defense
+	for _, m := range append([]*Monster(nil), w.Monsters...) {
+		if m.Zone != defenseZone || !overlaps(m.X, m.Y, m.W, m.H, camp.X, camp.Y, camp.Width, camp.Height) {
+			continue
+		}
+		d.CampLife = max(d.CampLife-max(m.Damage, 1), 0)
+		w.RemoveMonsterFromMap(m)
+		w.CombatMsg = w.say(fmt.Sprintf("%s attaque le camp ! (%d/%d)", m.Name, d.CampLife, camp.Life))
+		if d.CampLife == 0 {
+			d.Phase = PhaseLost
+			w.CombatMsg = w.say(fmt.Sprintf("Le camp est tombé ! Vagues repoussées : %d", d.Survived))
+			return
+		}
+	}
+}
+
+// ----------------- Affichage -----------------
+
+// DrawDefense dessine le camp, sa vie et l'état des vagues
+func DrawDefense(screen *ebiten.Image, w *World) {
+	d := w.Defense
+	if d == nil {
+		return
+	}
+	camp := DefaultDefense().Camp
+	x, y := int(camp.X), int(camp.Y)
+	drawRectBar(screen, x, y, int(camp.Width), int(camp.Height), color.RGBA{0, 160, 200, 60})
+	drawOutline(screen, x, y, int(camp.Width), int(camp.Height), color.RGBA{0, 120, 160, 255})
+	drawRectBar(screen, x, y-12, int(camp.Width), 6, color.RGBA{60, 0, 0, 200})
+	drawRectBar(screen, x, y-12, int(camp.Width)*d.CampLife/camp.Life, 6, color.RGBA{0, 200, 80, 255})
+	text.Draw(screen, "Camp", basicfont.Face7x13, x+4, y+16, color.White)
+
+	status := fmt.Sprintf("Vague %d | Camp %d/%d | Score : %d", d.Wave, d.CampLife, camp.Life, d.Survived)
+	switch d.Phase {
+	case PhaseWaiting:
+		secs := (d.NextSpawn - w.Tick + TicksPerSecond - 1) / TicksPerSecond
+		status += fmt.Sprintf(" | Prochaine vague dans %d s", max(secs, 0))
+	case PhaseWave:
+		status += fmt.Sprintf(" | Monstres restants : %d", len(d.Queue)+w.population(defenseZone))
+	}
+	drawCenteredText(screen, status, 20, color.White)
+}
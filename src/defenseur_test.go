@@ -0,0 +1,149 @@
+package source
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// defenderWorld crée une partie Défenseur où le joueur attend loin du
+// chemin des monstres
+func defenderWorld(seed uint64) *World {
+	w := NewDefenderWorld(seed)
+	w.Player.PosX, w.Player.PosY = 100, 500
+	return w
+}
+
+// attackers retourne les monstres des vagues présents sur la map
+func attackers(w *World) []*Monster {
+	var ms []*Monster
+	for _, m := range w.Monsters {
+		if m.Zone == defenseZone {
+			ms = append(ms, m)
+		}
+	}
+	return ms
+}
+
+func TestDefenseWaves(t *testing.T) {
+	w := defenderWorld(3)
+	c := DefaultDefense()
+	d := w.Defense
+
+	// Compte à rebours, puis la première vague arrive monstre par monstre
+	for i := 0; d.Phase == PhaseWaiting; i++ {
+		if i > int(c.Delay*TicksPerSecond) {
+			t.Fatal("la première vague n'arrive pas")
+		}
+		w.Update(Input{})
+	}
+	if len(d.Queue) != len(c.Wave(1)) {
+		t.Fatalf("vague 1 : %d monstres, attendu %d", len(d.Queue), len(c.Wave(1)))
+	}
+
+	// Chaque monstre arrivé est vaincu : la vague est repoussée
+	money := 0
+	for i := 0; d.Phase == PhaseWave; i++ {
+		if i > 60*TicksPerSecond {
+			t.Fatal("la vague ne se termine pas")
+		}
+		for _, m := range attackers(w) {
+			m.Health = 0
+			w.StartCombat(m)
+			w.EndCombat()
+		}
+		money = w.Player.Money
+		w.Update(Input{})
+	}
+	if d.Phase != PhaseShop || !w.ShopOpen {
+		t.Fatalf("phase %d, marchand ouvert %v", d.Phase, w.ShopOpen)
+	}
+	if d.Survived != 1 || d.Wave != 2 || d.CampLife != c.Camp.Life || w.Player.Money != money+c.Bonus {
+		t.Errorf("score %d, vague %d, camp %d, or %d (attendu +%d)", d.Survived, d.Wave, d.CampLife, w.Player.Money-money, c.Bonus)
+	}
+
+	// Tant que le marchand est ouvert, la vague suivante attend
+	for i := 0; i < 2*int(c.Delay*TicksPerSecond); i++ {
+		w.Update(Input{})
+	}
+	if d.Phase != PhaseShop || len(w.Monsters) != 0 {
+		t.Fatalf("phase %d, %d monstres pendant le marchand", d.Phase, len(w.Monsters))
+	}
+	w.Update(Input{CloseShop: true})
+	w.Update(Input{})
+	if d.Phase != PhaseWaiting || d.NextSpawn != w.Tick+int(c.Delay*TicksPerSecond) {
+		t.Errorf("après le marchand : phase %d, vague au tick %d", d.Phase, d.NextSpawn)
+	}
+}
+
+func TestDefenseWaveList(t *testing.T) {
+	c := &DefenseConfig{Waves: []WaveDef{
+		{Monsters: []WaveGroup{{Monster: "Scorpion", Count: 2}}},
+		{Monsters: []WaveGroup{{Monster: "Serpent", Count: 1}, {Monster: "Hyène", Count: 1}}},
+	}}
+	tests := []struct {
+		wave int
+		want []string
+	}{
+		{1, []string{"Scorpion", "Scorpion"}},
+		{2, []string{"Serpent", "Hyène"}},
+		{3, []string{"Serpent", "Serpent", "Hyène", "Hyène"}}, // Dernière vague, un monstre de plus par groupe
+		{4, []string{"Serpent", "Serpent", "Serpent", "Hyène", "Hyène", "Hyène"}},
+	}
+	for _, tt := range tests {
+		if got := c.Wave(tt.wave); !slices.Equal(got, tt.want) {
+			t.Errorf("vague %d : %v, attendu %v", tt.wave, got, tt.want)
+		}
+	}
+}
+
+func TestDefenseLost(t *testing.T) {
+	w := defenderWorld(5)
+	d := w.Defense
+	d.CampLife = 1
+
+	// Personne n'arrête les monstres : le premier arrivé fait tomber le camp
+	for i := 0; !d.Lost(); i++ {
+		if i > 60*TicksPerSecond {
+			t.Fatal("le camp ne tombe pas")
+		}
+		if w.Combat != nil {
+			t.Fatal("combat inattendu : le joueur devrait être loin des monstres")
+		}
+		w.Update(Input{})
+	}
+	if d.CampLife != 0 || !strings.Contains(w.CombatMsg.Text, "Le camp est tombé") {
+		t.Errorf("camp %d, message %q", d.CampLife, w.CombatMsg.Text)
+	}
+
+	// La partie est finie : plus rien ne bouge
+	p := w.Player
+	x, y := p.PosX, p.PosY
+	var before [][2]float64
+	for _, m := range w.Monsters {
+		before = append(before, [2]float64{m.X, m.Y})
+	}
+	for i := 0; i < TicksPerSecond; i++ {
+		w.Update(Input{Right: true, Down: true, ToggleInventory: true})
+	}
+	if p.PosX != x || p.PosY != y || w.InventoryOpen {
+		t.Error("le joueur bouge après la chute du camp")
+	}
+	for i, m := range w.Monsters {
+		if m.X != before[i][0] || m.Y != before[i][1] {
+			t.Errorf("%s bouge après la chute du camp", m.Name)
+		}
+	}
+}
+
+func TestDefenseNoSave(t *testing.T) {
+	w := defenderWorld(1)
+	if _, err := w.Save(); err == nil {
+		t.Error("sauvegarde acceptée en mode Défenseur")
+	}
+	w.checkpoint = true
+	w.Tick += AutosaveInterval
+	if w.TakeCheckpoint() {
+		t.Error("sauvegarde automatique en mode Défenseur")
+	}
+}
@@ -0,0 +1,126 @@
+package source
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ----------------- Données du jeu -----------------
+// Les fichiers du dossier data (monstres, objets...) décrivent le contenu du
+// jeu. Au lancement, le jeu lit src/data sur le disque s'il existe (modifier
+// un fichier puis relancer suffit, sans recompiler) et sinon la copie
+// intégrée au binaire. Tous les fichiers sont vérifiés dès le lancement.
+
+// dataDir est le dossier des données sur le disque (depuis la racine du projet)
+const dataDir = "src/data"
+
+//go:embed data/*.json
+var dataFiles embed.FS
+
+// readData lit un fichier du dossier data : sur le disque s'il existe,
+// sinon dans le binaire
+func readData(name string) ([]byte, error) {
+	if data, err := os.ReadFile(filepath.Join(dataDir, name)); err == nil {
+		return data, nil
+	}
+	return dataFiles.ReadFile("data/" + name)
+}
+
+// decodeData décode un fichier de données JSON. Les champs inconnus sont
+// refusés pour qu'une faute de frappe ne passe pas inaperçue.
+func decodeData(name string, data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%s : %w", name, err)
+	}
+	return nil
+}
+
+// validated est le contenu d'un fichier de données, qui sait se vérifier
+type validated[T any] interface {
+	*T
+	validate() error
+}
+
+// parseData décode et valide le contenu d'un fichier de données
+func parseData[T any, P validated[T]](name string, data []byte) (*T, error) {
+	v := P(new(T))
+	if err := decodeData(name, data, v); err != nil {
+		return nil, err
+	}
+	if err := v.validate(); err != nil {
+		return nil, fmt.Errorf("%s : %w", name, err)
+	}
+	return v, nil
+}
+
+// loadData lit et valide un fichier de données sur le disque
+func loadData[T any, P validated[T]](path string) (*T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseData[T, P](path, data)
+}
+
+// dataFile est un fichier du dossier data, lu et validé une seule fois
+type dataFile[T any, P validated[T]] struct {
+	name  string
+	once  sync.Once
+	value *T
+	err   error
+}
+
+// load lit et valide le fichier au premier appel
+func (f *dataFile[T, P]) load() (*T, error) {
+	f.once.Do(func() {
+		data, err := readData(f.name)
+		if err != nil {
+			f.err = err
+			return
+		}
+		f.value, f.err = parseData[T, P](f.name, data)
+	})
+	return f.value, f.err
+}
+
+// get retourne le contenu du fichier ; un fichier invalide arrête le jeu
+func (f *dataFile[T, P]) get() *T {
+	v, err := f.load()
+	if err != nil {
+		log.Fatalf("Données du jeu invalides (%s/%s) :\n%v", dataDir, f.name, err)
+	}
+	return v
+}
+
+// check vérifie le fichier sans arrêter le jeu
+func (f *dataFile[T, P]) check() error {
+	_, err := f.load()
+	return err
+}
+
+// Fichiers du dossier data
+var (
+	itemsData    = &dataFile[ItemRegistry, *ItemRegistry]{name: "objets.json"}
+	bestiaryData = &dataFile[Bestiary, *Bestiary]{name: "monstres.json"}
+	skillsData   = &dataFile[SkillRegistry, *SkillRegistry]{name: "competences.json"}
+	defenseData  = &dataFile[DefenseConfig, *DefenseConfig]{name: "defenseur.json"}
+)
+
+// CheckData lit et valide tous les fichiers du dossier data, dans l'ordre
+// de leurs références (les monstres citent des objets, les vagues des monstres)
+func CheckData() error {
+	for _, f := range []interface{ check() error }{itemsData, bestiaryData, skillsData, defenseData} {
+		if err := f.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
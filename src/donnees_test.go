@@ -0,0 +1,108 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// dataParsers associe chaque fichier du dossier data à sa lecture
+var dataParsers = map[string]func(name string, data []byte) error{
+	"objets.json": func(name string, data []byte) error {
+		_, err := ParseItems(name, data)
+		return err
+	},
+	"monstres.json": func(name string, data []byte) error {
+		_, err := ParseBestiary(name, data)
+		return err
+	},
+	"competences.json": func(name string, data []byte) error {
+		_, err := ParseSkills(name, data)
+		return err
+	},
+	"defenseur.json": func(name string, data []byte) error {
+		_, err := ParseDefense(name, data)
+		return err
+	},
+}
+
+func TestEmbeddedData(t *testing.T) {
+	entries, err := dataFiles.ReadDir("data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		t.Run(e.Name(), func(t *testing.T) {
+			parse, ok := dataParsers[e.Name()]
+			if !ok {
+				t.Fatal("fichier de données sans lecture : l'ajouter à dataParsers et à CheckData")
+			}
+			data, err := dataFiles.ReadFile("data/" + e.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := parse(e.Name(), data); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	if err := CheckData(); err != nil {
+		t.Errorf("CheckData : %v", err)
+	}
+}
+
+func TestDataErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string // Morceaux attendus dans le message
+	}{
+		{"mauvais type", `{"monsters": [{"name": "Fennec", "health": "beaucoup"}]}`, []string{"monstres.json", "health"}},
+		{"champ inconnu", `{"monsters": [{"name": "Fennec", "vie": 10}]}`, []string{"monstres.json", "vie"}},
+		{"valeur invalide", `{"monsters": [{"name": "Fennec", "sprites": ["fennec.png"], "scale": 1, "width": 10, "height": 10, "health": 0}]}`, []string{"monstres.json", "Fennec", "points de vie"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBestiary("monstres.json", []byte(tt.data))
+			if err == nil {
+				t.Fatal("fichier invalide accepté")
+			}
+			for _, part := range tt.want {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("%q ne cite pas %q", err, part)
+				}
+			}
+		})
+	}
+}
+
+func TestReadDataFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, dataDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, dataDir, "objets.json"), []byte(`{"items": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	// Le fichier du disque remplace la copie intégrée, les autres restent intégrés
+	if data, err := readData("objets.json"); err != nil || string(data) != `{"items": []}` {
+		t.Errorf("objets.json : %q, %v", data, err)
+	}
+	embedded, _ := dataFiles.ReadFile("data/monstres.json")
+	if data, err := readData("monstres.json"); err != nil || string(data) != string(embedded) {
+		t.Errorf("monstres.json non lu dans le binaire : %v", err)
+	}
+}
+
+// swapData remplace le contenu d'un fichier de données le temps d'un test
+func swapData[T any, P validated[T]](t *testing.T, f **dataFile[T, P], v *T) {
+	t.Helper()
+	old := *f
+	swapped := &dataFile[T, P]{name: old.name, value: v}
+	swapped.once.Do(func() {})
+	*f = swapped
+	t.Cleanup(func() { *f = old })
+}
@@ -0,0 +1,152 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------- Équipement -----------------
+// Le joueur porte au plus un objet par emplacement. Un objet équipé quitte
+// l'inventaire et ses bonus s'ajoutent aux statistiques de base du joueur
+// (niveaux et points répartis) tant qu'il est porté.
+
+// EquipSlot est un emplacement d'équipement
+type EquipSlot string
+
+const (
+	SlotWeapon    EquipSlot = "weapon"    // Arme
+	SlotHead      EquipSlot = "head"      // Tête
+	SlotBody      EquipSlot = "body"      // Corps
+	SlotFeet      EquipSlot = "feet"      // Pieds
+	SlotAccessory EquipSlot = "accessory" // Accessoire
+)
+
+// EquipSlots liste les emplacements dans l'ordre d'affichage
+var EquipSlots = []EquipSlot{SlotWeapon, SlotHead, SlotBody, SlotFeet, SlotAccessory}
+
+var equipSlotNames = map[EquipSlot]string{
+	SlotWeapon:    "Arme",
+	SlotHead:      "Tête",
+	SlotBody:      "Corps",
+	SlotFeet:      "Pieds",
+	SlotAccessory: "Accessoire",
+}
+
+// String retourne le nom affiché de l'emplacement
+func (s EquipSlot) String() string {
+	if name, ok := equipSlotNames[s]; ok {
+		return name
+	}
+	return string(s)
+}
+
+// Stats regroupe les statistiques modifiées par l'équipement
+type Stats struct {
+	MaxLife   int // Points de vie max
+	MaxShield int // Bouclier max
+	Strength  int // Force
+	Defense   int // Défense
+}
+
+// ErrNotEquippable signale un objet sans emplacement d'équipement
+var ErrNotEquippable = errors.New("objet impossible à équiper")
+
+// Equiper porte un objet de l'inventaire. L'objet déjà porté à cet
+// emplacement retourne dans l'inventaire.
+func (p *Personnage) Equiper(id string) error {
+	def, ok := DefaultItems().Item(id)
+	if !ok {
+		return fmt.Errorf("%w : %q", ErrUnknownItem, id)
+	}
+	if def.Slot == "" {
+		return fmt.Errorf("%w : %s", ErrNotEquippable, def.Name)
+	}
+	if !p.RetirerItem(id) {
+		return fmt.Errorf("%s n'est pas dans l'inventaire", def.Name)
+	}
+	if old := p.Equipment[def.Slot]; old != "" {
+		if err := p.AjouterItem(old); err != nil {
+			p.AjouterItem(id) // Annule : l'objet reprend sa place
+			return err
+		}
+	}
+	if p.Equipment == nil {
+		p.Equipment = map[EquipSlot]string{}
+	}
+	p.Equipment[def.Slot] = id
+	p.RecalculerStats()
+	fmt.Printf("%s équipe %s.\n", p.Name, def.Name)
+	return nil
+}
+
+// Desequiper retire l'objet porté à un emplacement et le remet dans l'inventaire
+func (p *Personnage) Desequiper(slot EquipSlot) error {
+	id := p.Equipment[slot]
+	if id == "" {
+		return fmt.Errorf("rien n'est équipé : %s", slot)
+	}
+	if err := p.AjouterItem(id); err != nil {
+		return err
+	}
+	delete(p.Equipment, slot)
+	p.RecalculerStats()
+	fmt.Printf("%s retire %s.\n", p.Name, ItemName(id))
+	return nil
+}
+
+// RecalculerStats calcule les statistiques à partir de la base, de
+// l'équipement et des bonus des effets actifs (rage)
+func (p *Personnage) RecalculerStats() {
+	st := p.Base
+	for _, id := range p.Equipment {
+		def, ok := DefaultItems().Item(id)
+		if !ok {
+			continue
+		}
+		st.MaxLife += def.Effect(EffectRaiseMaxLife)
+		st.MaxShield += def.Effect(EffectRaiseMaxShield)
+		st.Strength += def.Effect(EffectStrength)
+		st.Defense += def.Effect(EffectDefense)
+	}
+	st.Strength += p.Bonus.Strength
+	p.MaxLife, p.MaxShield, p.Strength, p.Defense = st.MaxLife, st.MaxShield, st.Strength, st.Defense
+	if p.Life > p.MaxLife {
+		p.Life = p.MaxLife
+	}
+	if p.Shield > p.MaxShield {
+		p.Shield = p.MaxShield
+	}
+}
+
+// Porte indique si l'objet est équipé
+func (p *Personnage) Porte(id string) bool {
+	for _, eq := range p.Equipment {
+		if eq == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Arme retourne l'arme équipée
+func (p *Personnage) Arme() (Weapon, bool) {
+	def, ok := DefaultItems().Item(p.Equipment[SlotWeapon])
+	if !ok {
+		return Weapon{}, false
+	}
+	return Weapon{Name: def.Name, Damage: def.Effect(EffectWeaponDamage)}, true
+}
+
+// checkEquipment vérifie que chaque objet porté existe et va à sa place
+func checkEquipment(eq map[EquipSlot]string) error {
+	for slot, id := range eq {
+		def, ok := DefaultItems().Item(id)
+		if !ok {
+			return fmt.Errorf("%w : %q", ErrUnknownItem, id)
+		}
+		if def.Slot != slot {
+			return fmt.Errorf("%s équipé à la mauvaise place : %s", def.Name, slot)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package source
+
+import (
+	"errors"
+	"testing"
+)
+
+// stats retourne les statistiques actuelles du joueur
+func stats(p *Personnage) Stats {
+	return Stats{MaxLife: p.MaxLife, MaxShield: p.MaxShield, Strength: p.Strength, Defense: p.Defense}
+}
+
+// nakedPlayer retourne un joueur sans équipement et à l'inventaire vide
+func nakedPlayer(t *testing.T, items ...string) *Personnage {
+	t.Helper()
+	p := NewWorldSeed(1).Player
+	p.Inventory, p.Equipment = nil, nil
+	p.RecalculerStats()
+	for _, id := range items {
+		if err := p.AjouterItem(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p
+}
+
+func TestEquipRevert(t *testing.T) {
+	p := nakedPlayer(t, "armure", "chapeau")
+	base := stats(p)
+
+	for _, id := range []string{"armure", "chapeau"} {
+		if err := p.Equiper(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := base
+	want.MaxShield += 40
+	want.Defense += 4
+	if got := stats(p); got != want || len(p.Inventory) != 0 {
+		t.Fatalf("équipé : %+v, attendu %+v (%d cases)", got, want, len(p.Inventory))
+	}
+
+	// Le shield au maximum redescend avec le maximum
+	p.Shield = p.MaxShield
+	if err := p.Desequiper(SlotBody); err != nil {
+		t.Fatal(err)
+	}
+	if p.Shield != p.MaxShield || p.MaxShield != base.MaxShield+10 {
+		t.Errorf("shield %d/%d sans armure, attendu %d", p.Shield, p.MaxShield, base.MaxShield+10)
+	}
+	if err := p.Desequiper(SlotHead); err != nil {
+		t.Fatal(err)
+	}
+	if got := stats(p); got != base || p.CompterItem("armure") != 1 || p.CompterItem("chapeau") != 1 {
+		t.Errorf("tout retiré : %+v, attendu %+v", got, base)
+	}
+
+	// La vie ne dépasse jamais le maximum recalculé
+	p.Life = p.MaxLife
+	p.Base.MaxLife -= 30
+	p.RecalculerStats()
+	if p.Life != p.MaxLife || p.MaxLife != base.MaxLife-30 {
+		t.Errorf("vie %d/%d, attendu %d", p.Life, p.MaxLife, base.MaxLife-30)
+	}
+}
+
+func TestEquipSwap(t *testing.T) {
+	p := nakedPlayer(t, "epee", "epee_amelioree", "plante_curative")
+
+	if err := p.Equiper("epee"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Equiper("epee_amelioree"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Equipment[SlotWeapon] != "epee_amelioree" || p.CompterItem("epee") != 1 || p.CompterItem("epee_amelioree") != 0 {
+		t.Errorf("arme %q, inventaire %v", p.Equipment[SlotWeapon], p.Inventory)
+	}
+	if err := p.Equiper("plante_curative"); !errors.Is(err, ErrNotEquippable) {
+		t.Errorf("plante équipée : %v", err)
+	}
+	if err := p.Equiper("armure"); err == nil {
+		t.Error("objet absent de l'inventaire équipé")
+	}
+
+	// Sans place dans l'inventaire, l'arme reste en main
+	p.Capacity = len(p.Inventory)
+	if err := p.Desequiper(SlotWeapon); !errors.Is(err, ErrInventoryFull) || p.Equipment[SlotWeapon] != "epee_amelioree" {
+		t.Errorf("retrait avec un inventaire plein : %v, arme %q", err, p.Equipment[SlotWeapon])
+	}
+	if err := p.Desequiper(SlotFeet); err == nil {
+		t.Error("retrait d'un emplacement vide accepté")
+	}
+}
@@ -0,0 +1,120 @@
+package source
+
+import (
+	"fmt"
+	"math"
+)
+
+// ----------------- Fuite -----------------
+// Fuir est une action du tour du joueur. La chance de réussir dépend du
+// rapport entre la vitesse du joueur et celle du monstre le plus rapide du
+// groupe. Une fuite réussie peut coûter un dernier coup d'un monstre ou un
+// peu d'or ; le joueur est alors repoussé hors de portée et ne peut plus être attaqué pendant
+// quelques secondes. Une fuite ratée fait perdre le tour.
+
+// Réglages de la fuite
+const (
+	fleeBaseChance = 0.25               // Chance de base
+	fleeSpeedBonus = 0.25               // Chance ajoutée par point de rapport de vitesse
+	fleeMaxChance  = 0.9                // Chance maximum (monstre immobile...)
+	fleeHitChance  = 0.3                // Chance de prendre un dernier coup en partant
+	fleeGoldChance = 0.3                // Chance de perdre de l'or en partant
+	fleeGoldShare  = 10                 // Part de l'or perdue, en %
+	fleeMargin     = 32                 // Distance gardée avec le monstre après la fuite
+	fleeImmunity   = 3 * TicksPerSecond // Durée sans combat après la fuite (3 s)
+)
+
+// FleeChance retourne la chance de fuir face aux monstres (0 à 1) :
+// seul le plus rapide compte
+func (w *World) FleeChance(ms ...*Monster) float64 {
+	speed := 0.0
+	for _, m := range ms {
+		if m.Vivant() {
+			speed = max(speed, m.Speed)
+		}
+	}
+	if speed <= 0 {
+		return fleeMaxChance
+	}
+	return min(fleeBaseChance+fleeSpeedBonus*w.PlayerSpeed/speed, fleeMaxChance)
+}
+
+// Immune indique si le joueur ne peut pas encore être attaqué après une fuite
+func (w *World) Immune() bool {
+	return w.Tick < w.SafeUntil
+}
+
+// flee tente de fuir le combat pendant le tour du joueur
+func (w *World) flee() {
+	c := w.Combat
+	p := w.Player
+	r := w.RNG.Combat()
+	living := c.Living()
+	if r.Float64() >= w.FleeChance(living...) {
+		w.CombatMsg = w.say(fmt.Sprintf("Fuite ratée ! %s vous barre la route.", living[0].Name))
+		c.PlayerTurn = false
+		return
+	}
+
+	// Le premier monstre qui peut encore frapper porte le dernier coup
+	var striker *Monster
+	for _, m := range living {
+		if !m.Statuts.Has(StatusStun) {
+			striker = m
+			break
+		}
+	}
+
+	w.CombatMsg = w.say("Vous prenez la fuite !")
+	switch roll := r.Float64(); {
+	case roll < fleeHitChance && striker != nil:
+		// Dernier coup du monstre dans le dos du joueur
+		w.frapper(striker.Strike(), p)
+		if !p.Vivant() {
+			w.defeat()
+			return
+		}
+	case roll < fleeHitChance+fleeGoldChance:
+		if lost := p.Money * fleeGoldShare / 100; lost > 0 {
+			p.Money -= lost
+			w.combatLog(fmt.Sprintf("Vous perdez %d pièces d'or dans la fuite.", lost))
+		}
+	}
+
+	w.EndCombat()
+	w.pushAway(living)
+	w.SafeUntil = w.Tick + fleeImmunity
+}
+
+// pushAway éloigne le joueur du groupe de monstres jusqu'à n'en toucher aucun
+func (w *World) pushAway(ms []*Monster) {
+	p := w.Player
+	var cx, cy float64
+	for _, m := range ms {
+		mx, my := m.center()
+		cx, cy = cx+mx/float64(len(ms)), cy+my/float64(len(ms))
+	}
+	dx := (p.PosX + p.Width/2) - cx
+	dy := (p.PosY + p.Height/2) - cy
+	dist := math.Hypot(dx, dy)
+	if dist == 0 {
+		dx, dy, dist = 0, 1, 1
+	}
+	dx, dy = dx/dist, dy/dist
+
+	touching := func() bool {
+		for _, m := range ms {
+			if overlaps(p.PosX, p.PosY, p.Width, p.Height, m.X, m.Y, m.W, m.H) {
+				return true
+			}
+		}
+		return false
+	}
+	// Recule pas à pas, puis garde une marge
+	for i := 0; i < 100 && touching(); i++ {
+		p.PosX += dx * 4
+		p.PosY += dy * 4
+	}
+	p.PosX += dx * fleeMargin
+	p.PosY += dy * fleeMargin
+}
@@ -0,0 +1,85 @@
+package source
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFleeChance(t *testing.T) {
+	w := NewWorldSeed(1)
+	speed := w.PlayerSpeed
+	monster := func(s float64, health int) *Monster {
+		return &Monster{Speed: s, Health: health}
+	}
+	tests := []struct {
+		name string
+		ms   []*Monster
+		want float64
+	}{
+		{"immobile", []*Monster{monster(0, 10)}, fleeMaxChance},
+		{"même vitesse", []*Monster{monster(speed, 10)}, fleeBaseChance + fleeSpeedBonus},
+		{"deux fois plus rapide", []*Monster{monster(2*speed, 10)}, fleeBaseChance + fleeSpeedBonus/2},
+		{"très lent", []*Monster{monster(speed/10, 10)}, fleeMaxChance},
+		// Seul le plus rapide des vivants compte
+		{"groupe", []*Monster{monster(speed, 10), monster(2*speed, 10), monster(10*speed, 0)}, fleeBaseChance + fleeSpeedBonus/2},
+	}
+	for _, tt := range tests {
+		if got := w.FleeChance(tt.ms...); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s : %.3f, attendu %.3f", tt.name, got, tt.want)
+		}
+	}
+}
+
+// fleeOutcome tente une fuite face au premier monstre d'une partie et
+// retourne son issue
+func fleeOutcome(t *testing.T, seed uint64) string {
+	t.Helper()
+	w := NewWorldSeed(seed)
+	p := w.Player
+	m := w.Monsters[0]
+	m.X, m.Y = p.PosX, p.PosY
+	p.Money = 100
+	w.StartCombat(m)
+	hp := p.Life + p.Shield // Le coup peut tomber sur le shield
+
+	w.flee()
+	if w.Combat != nil {
+		if w.Combat.PlayerTurn {
+			t.Errorf("graine %d : fuite ratée sans perdre le tour", seed)
+		}
+		return "ratée"
+	}
+	if !w.Immune() || w.SafeUntil != w.Tick+fleeImmunity {
+		t.Errorf("graine %d : pas d'immunité après la fuite", seed)
+	}
+	if overlaps(p.PosX, p.PosY, p.Width, p.Height, m.X, m.Y, m.W, m.H) {
+		t.Errorf("graine %d : joueur toujours au contact du monstre", seed)
+	}
+	switch {
+	case p.Life+p.Shield < hp && p.Money == 100:
+		return "coup"
+	case p.Money == 100-fleeGoldShare && p.Life+p.Shield == hp:
+		return "or"
+	case p.Money == 100 && p.Life+p.Shield == hp:
+		return "réussie"
+	}
+	t.Errorf("graine %d : vie et shield %d/%d, or %d après la fuite", seed, p.Life+p.Shield, hp, p.Money)
+	return ""
+}
+
+func TestFleeOutcomes(t *testing.T) {
+	seen := map[string]int{}
+	for seed := uint64(0); seed < 40; seed++ {
+		outcome := fleeOutcome(t, seed)
+		// Une graine rejoue toujours la même fuite
+		if again := fleeOutcome(t, seed); again != outcome {
+			t.Errorf("graine %d : %s puis %s", seed, outcome, again)
+		}
+		seen[outcome]++
+	}
+	for _, outcome := range []string{"ratée", "coup", "or", "réussie"} {
+		if seen[outcome] == 0 {
+			t.Errorf("issue %q jamais tirée en 40 graines : %v", outcome, seen)
+		}
+	}
+}
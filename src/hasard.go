@@ -0,0 +1,101 @@
+package source
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// ----------------- Hasard -----------------
+// Tout le hasard du jeu passe par le RNG de la partie, créé à partir d'une
+// graine. Chaque usage a son propre flux : un tirage de plus en combat ne
+// change pas le butin ni la map. L'état des flux est sauvegardé avec la
+// partie, donc une graine (ou une sauvegarde) rejoue exactement la session.
+
+// RNGStream identifie un flux de nombres aléatoires
+type RNGStream int
+
+const (
+	StreamCombat RNGStream = iota // Combat (coups critiques, fuite...)
+	StreamLoot                    // Butin des monstres
+	StreamWorld                   // Map (apparition et déplacement des monstres)
+	streamCount
+)
+
+var streamNames = [streamCount]string{
+	StreamCombat: "combat",
+	StreamLoot:   "loot",
+	StreamWorld:  "world",
+}
+
+// String retourne le nom du flux (utilisé dans les sauvegardes)
+func (s RNGStream) String() string {
+	if s >= 0 && s < streamCount {
+		return streamNames[s]
+	}
+	return fmt.Sprintf("RNGStream(%d)", int(s))
+}
+
+// RNG regroupe les flux aléatoires d'une partie
+type RNG struct {
+	Seed uint64 // Graine de la partie
+
+	sources [streamCount]*rand.PCG
+	streams [streamCount]*rand.Rand
+}
+
+// NewRNG crée les flux d'une partie à partir de sa graine
+func NewRNG(seed uint64) *RNG {
+	r := &RNG{Seed: seed}
+	for s := RNGStream(0); s < streamCount; s++ {
+		// Le numéro du flux sert de seconde graine : flux indépendants
+		r.sources[s] = rand.NewPCG(seed, uint64(s)+1)
+		r.streams[s] = rand.New(r.sources[s])
+	}
+	return r
+}
+
+// Stream retourne un flux aléatoire
+func (r *RNG) Stream(s RNGStream) *rand.Rand {
+	return r.streams[s]
+}
+
+// Combat retourne le flux du combat
+func (r *RNG) Combat() *rand.Rand { return r.streams[StreamCombat] }
+
+// Loot retourne le flux du butin
+func (r *RNG) Loot() *rand.Rand { return r.streams[StreamLoot] }
+
+// World retourne le flux de la map
+func (r *RNG) World() *rand.Rand { return r.streams[StreamWorld] }
+
+// RNGState est l'état sauvegardé des flux aléatoires
+type RNGState struct {
+	Seed    uint64            `json:"seed"`
+	Streams map[string][]byte `json:"streams"` // État de chaque flux
+}
+
+// State capture l'état des flux
+func (r *RNG) State() RNGState {
+	st := RNGState{Seed: r.Seed, Streams: map[string][]byte{}}
+	for s := RNGStream(0); s < streamCount; s++ {
+		data, _ := r.sources[s].MarshalBinary() // Ne peut pas échouer
+		st.Streams[s.String()] = data
+	}
+	return st
+}
+
+// RNGFromState recrée les flux sauvegardés. Un flux absent de la
+// sauvegarde repart de la graine.
+func RNGFromState(st RNGState) (*RNG, error) {
+	r := NewRNG(st.Seed)
+	for s := RNGStream(0); s < streamCount; s++ {
+		data, ok := st.Streams[s.String()]
+		if !ok {
+			continue
+		}
+		if err := r.sources[s].UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("flux aléatoire %s : %w", s, err)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,73 @@
+package source
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// draws tire n nombres d'un flux
+func draws(r *RNG, s RNGStream, n int) []uint64 {
+	out := make([]uint64, n)
+	for i := range out {
+		out[i] = r.Stream(s).Uint64()
+	}
+	return out
+}
+
+func TestRNGStreams(t *testing.T) {
+	a, b := NewRNG(42), NewRNG(42)
+
+	// Des tirages en plus dans un flux ne décalent pas les autres
+	draws(a, StreamCombat, 10)
+	for _, s := range []RNGStream{StreamLoot, StreamWorld} {
+		if got, want := draws(a, s, 5), draws(b, s, 5); !slices.Equal(got, want) {
+			t.Errorf("flux %s décalé par le combat : %v, attendu %v", s, got, want)
+		}
+	}
+	if slices.Equal(draws(NewRNG(42), StreamCombat, 5), draws(NewRNG(42), StreamLoot, 5)) {
+		t.Error("les flux combat et loot donnent les mêmes nombres")
+	}
+	if slices.Equal(draws(NewRNG(42), StreamWorld, 5), draws(NewRNG(43), StreamWorld, 5)) {
+		t.Error("deux graines donnent les mêmes nombres")
+	}
+}
+
+func TestRNGState(t *testing.T) {
+	r := NewRNG(7)
+	draws(r, StreamCombat, 3)
+	draws(r, StreamWorld, 8)
+
+	// L'état passe par le JSON, comme dans une sauvegarde
+	data, err := json.Marshal(r.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var st RNGState
+	if err := json.Unmarshal(data, &st); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := RNGFromState(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for s := RNGStream(0); s < streamCount; s++ {
+		if got, want := draws(restored, s, 5), draws(r, s, 5); !slices.Equal(got, want) {
+			t.Errorf("flux %s : %v, attendu %v", s, got, want)
+		}
+	}
+
+	// Un flux absent repart de la graine, un état illisible est refusé
+	delete(st.Streams, "loot")
+	restored, err = RNGFromState(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := draws(restored, StreamLoot, 5), draws(NewRNG(7), StreamLoot, 5); !slices.Equal(got, want) {
+		t.Errorf("flux absent : %v, attendu %v", got, want)
+	}
+	st.Streams["world"] = []byte("abîmé")
+	if _, err := RNGFromState(st); err == nil {
+		t.Error("état de flux illisible accepté")
+	}
+}
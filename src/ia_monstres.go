@@ -0,0 +1,130 @@
+package source
+
+import "math"
+
+// ----------------- IA des monstres -----------------
+// Chaque monstre suit une petite machine à états, recalculée à chaque tick :
+//   - fuite quand sa vie est basse et que le joueur est proche ;
+//   - poursuite quand le joueur entre dans son rayon d'aggro, sans trop
+//     s'éloigner de son point de départ ;
+//   - sinon son comportement du bestiaire : immobile, promenade autour du
+//     point de départ ou garde de ce point (ou marche vers le camp en mode
+//     Défenseur).
+// Tout dépend du tick et du flux aléatoire de la map : une graine rejoue
+// exactement les mêmes déplacements.
+
+// AIState est l'état actuel de l'IA d'un monstre
+type AIState string
+
+const (
+	AIIdle   AIState = "idle"   // Immobile
+	AIWander AIState = "wander" // Se promène autour de son point de départ
+	AIChase  AIState = "chase"  // Poursuit le joueur
+	AIFlee   AIState = "flee"   // S'enfuit loin du joueur
+	AIGuard  AIState = "guard"  // Retourne à son poste et le garde
+	AIMarch  AIState = "march"  // Marche vers le camp (mode Défenseur)
+)
+
+// Réglages de l'IA
+const (
+	wanderPauseMin = 1 * TicksPerSecond // Pause minimum entre deux destinations
+	wanderPauseMax = 3 * TicksPerSecond // Pause maximum entre deux destinations
+	aiArrival      = 2.0                // Distance à laquelle une destination est atteinte
+)
+
+// center retourne le centre de la zone de collision du monstre
+func (m *Monster) center() (x, y float64) {
+	return m.X + m.W/2, m.Y + m.H/2
+}
+
+// updateMonsterAI choisit l'état du monstre puis le déplace
+func (w *World) updateMonsterAI(m *Monster) {
+	var def MonsterDef
+	if d, ok := DefaultBestiary().Def(m.Name); ok {
+		def = *d
+	}
+	p := w.Player
+	mx, my := m.center()
+	dx, dy := mx-(p.PosX+p.Width/2), my-(p.PosY+p.Height/2)
+	player := math.Hypot(dx, dy)
+	home := math.Hypot(m.X-m.HomeX, m.Y-m.HomeY)
+	leash := def.HomeRadius + def.AggroRadius
+	if m.Behaviour == BehaviourMarch {
+		leash = math.Inf(1) // Poursuit le joueur n'importe où
+	}
+
+	sees := def.AggroRadius > 0 && player < def.AggroRadius
+	low := def.FleeHealth > 0 && float64(m.Health) < def.FleeHealth*float64(m.MaxHealth)
+	state := m.defaultState()
+	switch {
+	case sees && low:
+		state = AIFlee
+	case sees && home < leash:
+		state = AIChase
+	}
+	if state != m.AI && state == AIWander {
+		m.AITick = w.Tick // Nouvelle destination dès le retour en promenade
+	}
+	m.AI = state
+
+	m.DirX, m.DirY = 0, 0
+	switch m.AI {
+	case AIChase:
+		m.headTo(p.PosX+p.Width/2-m.W/2, p.PosY+p.Height/2-m.H/2)
+	case AIFlee:
+		m.headTo(m.X+dx, m.Y+dy)
+	case AIGuard, AIMarch:
+		m.headTo(m.HomeX, m.HomeY)
+	case AIWander:
+		if w.Tick >= m.AITick {
+			r := w.RNG.World()
+			angle := r.Float64() * 2 * math.Pi
+			dist := r.Float64() * def.HomeRadius
+			m.TargetX = m.HomeX + math.Cos(angle)*dist
+			m.TargetY = m.HomeY + math.Sin(angle)*dist
+			m.AITick = w.Tick + wanderPauseMin + r.IntN(wanderPauseMax-wanderPauseMin+1)
+		}
+		m.headTo(m.TargetX, m.TargetY)
+	}
+	m.move(leash)
+}
+
+// defaultState retourne l'état du monstre quand le joueur est loin
+func (m *Monster) defaultState() AIState {
+	switch m.Behaviour {
+	case BehaviourWander:
+		return AIWander
+	case BehaviourGuard:
+		return AIGuard
+	case BehaviourMarch:
+		return AIMarch
+	}
+	return AIIdle
+}
+
+// headTo oriente le monstre vers (x, y), ou l'arrête s'il y est déjà
+func (m *Monster) headTo(x, y float64) {
+	dx, dy := x-m.X, y-m.Y
+	dist := math.Hypot(dx, dy)
+	if dist < aiArrival {
+		return
+	}
+	// Ralentit pour ne pas dépasser la destination
+	step := min(1, dist/max(m.Speed, 1e-9))
+	m.DirX, m.DirY = dx/dist*step, dy/dist*step
+}
+
+// move avance le monstre dans sa direction, sans sortir du cercle de
+// rayon leash autour de son point de départ
+func (m *Monster) move(leash float64) {
+	m.X += m.DirX * m.Speed
+	m.Y += m.DirY * m.Speed
+	dx, dy := m.X-m.HomeX, m.Y-m.HomeY
+	if dist := math.Hypot(dx, dy); dist > leash {
+		if leash <= 0 {
+			m.X, m.Y = m.HomeX, m.HomeY
+			return
+		}
+		m.X, m.Y = m.HomeX+dx/dist*leash, m.HomeY+dy/dist*leash
+	}
+}
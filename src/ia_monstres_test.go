@@ -0,0 +1,96 @@
+package source
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"testing"
+)
+
+// walkAround retourne les entrées d'un joueur qui tourne en rond autour du
+// camp et frappe dès qu'un combat commence
+func walkAround(tick int) Input {
+	switch (tick / 90) % 4 {
+	case 0:
+		return Input{Right: true, Punch: true}
+	case 1:
+		return Input{Down: true, Punch: true}
+	case 2:
+		return Input{Left: true, Punch: true}
+	}
+	return Input{Up: true, Punch: true}
+}
+
+func TestMonsterAISeed(t *testing.T) {
+	a, b := NewWorldSeed(21), NewWorldSeed(21)
+	start := make([][2]float64, len(a.Monsters))
+	for i, m := range a.Monsters {
+		start[i] = [2]float64{m.X, m.Y}
+	}
+	moved, timers := false, false
+
+	for tick := 0; tick < 20*TicksPerSecond; tick++ {
+		// Une zone se dépeuple : son délai de réapparition commence
+		if tick == 60 {
+			for _, w := range []*World{a, b} {
+				i := slices.IndexFunc(w.Monsters, func(m *Monster) bool { return m.Zone != "" })
+				w.RemoveMonsterFromMap(w.Monsters[i])
+			}
+		}
+		a.Update(walkAround(tick))
+		b.Update(walkAround(tick))
+
+		if len(a.Monsters) != len(b.Monsters) {
+			t.Fatalf("tick %d : %d et %d monstres", tick, len(a.Monsters), len(b.Monsters))
+		}
+		for i, ma := range a.Monsters {
+			mb := b.Monsters[i]
+			if ma.Name != mb.Name || ma.X != mb.X || ma.Y != mb.Y || ma.AI != mb.AI {
+				t.Fatalf("tick %d : %s %v,%v (%s) et %s %v,%v (%s)", tick, ma.Name, ma.X, ma.Y, ma.AI, mb.Name, mb.X, mb.Y, mb.AI)
+			}
+			if i < len(start) && (ma.X != start[i][0] || ma.Y != start[i][1]) {
+				moved = true
+			}
+		}
+		if !maps.Equal(a.Spawns, b.Spawns) {
+			t.Fatalf("tick %d : délais %v et %v", tick, a.Spawns, b.Spawns)
+		}
+		timers = timers || len(a.Spawns) > 0
+	}
+	if !moved || !timers {
+		t.Errorf("simulation trop calme : monstres déplacés %v, délais %v", moved, timers)
+	}
+}
+
+func TestMonsterLeash(t *testing.T) {
+	w := NewWorldSeed(1)
+	i := slices.IndexFunc(w.Monsters, func(m *Monster) bool { return m.Name == "Scorpion" })
+	m := w.Monsters[i]
+	def, _ := DefaultBestiary().Def(m.Name)
+	leash := def.HomeRadius + def.AggroRadius
+	p := w.Player
+
+	// Le joueur recule toujours juste à portée d'aggro : le monstre le suit
+	// jusqu'au bout de sa laisse, sans la dépasser
+	for tick := 0; tick < 30*TicksPerSecond; tick++ {
+		mx, my := m.center()
+		p.PosX, p.PosY = mx+def.AggroRadius-20-p.Width/2, my-p.Height/2
+		w.Tick++
+		w.updateMonsterAI(m)
+		if home := math.Hypot(m.X-m.HomeX, m.Y-m.HomeY); home > leash+1e-6 {
+			t.Fatalf("tick %d : %.1f du point de départ, laisse %.1f", tick, home, leash)
+		}
+	}
+	// Au bout de la laisse, le monstre hésite d'un pas entre poursuite et retour
+	if home := math.Hypot(m.X-m.HomeX, m.Y-m.HomeY); home < leash-2*m.Speed {
+		t.Errorf("arrêté à %.1f du point de départ, laisse %.1f", home, leash)
+	}
+
+	// Le joueur hors de portée : le monstre repart se promener
+	p.PosX, p.PosY = m.HomeX+2000, m.HomeY
+	w.Tick++
+	w.updateMonsterAI(m)
+	if m.AI != AIWander {
+		t.Errorf("état %s loin du joueur, attendu %s", m.AI, AIWander)
+	}
+}
@@ -3,101 +3,222 @@ package source
 import (
 	"fmt"
 	"image/color"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font/basicfont"
 )
 
-// InventaireGUI gère l'affichage et la logique de l'inventaire du joueur
+// InventaireGUI gère l'affichage de l'inventaire du joueur
 type InventaireGUI struct {
-	player           *Personnage // Référence au joueur
-	open             bool        // Inventaire ouvert ou fermé
-	keyPrevP         bool        // État précédent de la touche P
-	keyPrevMouseLeft bool        // État précédent du clic gauche
-	message          string      // Message temporaire affiché
-	msgTime          time.Time   // Temps d'affichage du message
+	world     *World // Partie affichée
+	Focus     int    // Case sélectionnée à la manette
+	ShowFocus bool   // Affiche la case sélectionnée (manette branchée)
+	InDoll    bool   // La sélection est sur le panneau d'équipement
+	DollFocus int    // Emplacement d'équipement sélectionné
+	Scroll    int    // Première ligne affichée de la grille
 }
 
-// Crée une nouvelle interface d'inventaire pour le joueur
-func NewInventaireGUI(player *Personnage) *InventaireGUI {
-	return &InventaireGUI{
-		player: player,
-		open:   false,
+// Crée une nouvelle interface d'inventaire pour la partie
+func NewInventaireGUI(w *World) *InventaireGUI {
+	return &InventaireGUI{world: w}
+}
+
+// Dimensions de la grille des items (inventaire et marchand)
+const (
+	gridCols  = 5
+	gridCellW = 110
+	gridCellH = 50
+)
+
+// gridRows retourne le nombre de lignes de la grille visibles dans le
+// panneau (sous le titre, au-dessus de la description et du message)
+func gridRows(screenH int) int {
+	rows := (screenH*2/5 - 140) / gridCellH
+	if rows < 1 {
+		rows = 1
 	}
+	return rows
 }
 
-// Met à jour l'état de l'inventaire (ouverture/fermeture, gestion des clics)
-func (inv *InventaireGUI) Update() {
-	keyP := ebiten.IsKeyPressed(ebiten.KeyP)
-	if keyP && !inv.keyPrevP {
-		inv.open = !inv.open
+// gridScroll garde la première ligne affichée dans les limites de la
+// grille et, si follow est vrai, fait défiler jusqu'à la case focus
+func gridScroll(scroll, focus, count, rows int, follow bool) int {
+	if follow {
+		if row := focus / gridCols; row < scroll {
+			scroll = row
+		} else if row >= scroll+rows {
+			scroll = row - rows + 1
+		}
+	}
+	total := (count + gridCols - 1) / gridCols
+	if scroll > total-rows {
+		scroll = total - rows
 	}
-	inv.keyPrevP = keyP
+	if scroll < 0 {
+		scroll = 0
+	}
+	return scroll
+}
+
+// gridSlotAt retourne la case de la grille sous le curseur, ou -1.
+// La grille est centrée dans un panneau de 3/5 x 2/5 de l'écran ;
+// scroll est la première ligne affichée.
+func gridSlotAt(mx, my, screenW, screenH, count, scroll int) int {
+	width, height := screenW*3/5, screenH*2/5
+	x := (screenW - width) / 2
+	y := (screenH - height) / 2
+	startX := x + 20
+	startY := y + 90
+	rows := gridRows(screenH)
+
+	for i := scroll * gridCols; i < count && i < (scroll+rows)*gridCols; i++ {
+		itemX := startX + (i%gridCols)*gridCellW
+		itemY := startY + (i/gridCols-scroll)*gridCellH
+		if mx >= itemX && mx <= itemX+gridCellW-10 && my >= itemY && my <= itemY+gridCellH-10 {
+			return i
+		}
+	}
+	return -1
+}
+
+// drawScrollMarks indique qu'il reste des lignes au-dessus ou en dessous
+func drawScrollMarks(screen *ebiten.Image, x, startY, scroll, count, rows int) {
+	face := basicfont.Face7x13
+	brown := color.RGBA{101, 67, 33, 255}
+	if scroll > 0 {
+		text.Draw(screen, "^", face, x, startY+10, brown)
+	}
+	if (scroll+rows)*gridCols < count {
+		text.Draw(screen, "v", face, x, startY+rows*gridCellH-15, brown)
+	}
+}
 
-	if !inv.open {
+// gridMove déplace le focus dans une grille de count cases
+func gridMove(focus, dx, dy, count int) int {
+	if count == 0 {
+		return 0
+	}
+	focus += dx + dy*gridCols
+	if focus < 0 {
+		focus = 0
+	}
+	if focus >= count {
+		focus = count - 1
+	}
+	return focus
+}
+
+// drawFocusRing entoure la case sélectionnée à la manette
+func drawFocusRing(screen *ebiten.Image, itemX, itemY, radius int) {
+	drawRoundedRect(screen, itemX-3, itemY-3, gridCellW-4, gridCellH-4, radius+3, color.RGBA{101, 67, 33, 255})
+}
+
+// Navigate déplace la case sélectionnée de l'inventaire. À gauche de la
+// première colonne, la sélection passe sur le panneau d'équipement.
+func (inv *InventaireGUI) Navigate(dx, dy int) {
+	count := len(inv.world.Player.Inventory)
+	if inv.InDoll {
+		if dx > 0 && count > 0 {
+			inv.InDoll = false
+			return
+		}
+		inv.DollFocus = (inv.DollFocus + dy + len(EquipSlots)) % len(EquipSlots)
 		return
 	}
+	if dx < 0 && (count == 0 || inv.Focus%gridCols == 0) {
+		inv.InDoll = true
+		return
+	}
+	inv.Focus = gridMove(inv.Focus, dx, dy, count)
+}
 
-	// Gestion du clic sur les items (front du clic gauche)
-	mouseLeft := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
-	if mouseLeft && !inv.keyPrevMouseLeft {
-		mx, my := ebiten.CursorPosition()
-		colSize := 5
-		cellW, cellH := 110, 50
-		screenW, screenH := ebiten.ScreenSizeInFullscreen()
-		width, height := screenW*3/5, screenH*2/5
-		x := (screenW - width) / 2
-		y := (screenH - height) / 2
-		startX := x + 20
-		startY := y + 90
-
-		for i, item := range inv.player.Inventory {
-			col := i % colSize
-			row := i / colSize
-			itemX := startX + col*cellW
-			itemY := startY + row*cellH
-
-			if float64(mx) >= float64(itemX) && float64(mx) <= float64(itemX+cellW-10) &&
-				float64(my) >= float64(itemY) && float64(my) <= float64(itemY+cellH-10) {
-
-				// Applique l'effet de l'item
-				switch item {
-				case "Plante curative":
-					inv.player.Soigner(50)
-					inv.message = fmt.Sprintf("%s utilise %s ! Vie: %d/%d", inv.player.Name, item, inv.player.Life, inv.player.MaxLife)
-				case "Potion magique":
-					inv.player.AjouterShield(10)
-					inv.message = fmt.Sprintf("%s utilise %s ! Shield: %d/%d", inv.player.Name, item, inv.player.Shield, inv.player.MaxShield)
-				case "Armure":
-					inv.player.MaxShield += 30
-					inv.message = fmt.Sprintf("%s utilise %s ! MaxShield: %d", inv.player.Name, item, inv.player.MaxShield)
-				case "Botte":
-					inv.player.MaxShield += 20
-					inv.message = fmt.Sprintf("%s utilise %s ! MaxShield: %d", inv.player.Name, item, inv.player.MaxShield)
-				case "Chapeau":
-					inv.player.MaxShield += 10
-					inv.message = fmt.Sprintf("%s utilise %s ! MaxShield: %d", inv.player.Name, item, inv.player.MaxShield)
-				default:
-					inv.message = fmt.Sprintf("%s ne peut pas utiliser %s", inv.player.Name, item)
-				}
-
-				// Retire l'item après usage
-				inv.player.Inventory = append(inv.player.Inventory[:i], inv.player.Inventory[i+1:]...)
-				inv.msgTime = time.Now()
-				break
-			}
+// ScrollBy fait défiler la grille de quelques lignes (molette)
+func (inv *InventaireGUI) ScrollBy(rows int) {
+	inv.Scroll += rows
+}
+
+// Panneau d'équipement, à gauche de l'inventaire
+const (
+	dollW     = 200
+	dollSlotH = 45
+)
+
+// dollRect retourne la position et la taille du panneau d'équipement
+func dollRect(screenW, screenH int) (x, y, w, h int) {
+	width, height := screenW*3/5, screenH*2/5
+	return (screenW-width)/2 - dollW - 15, (screenH - height) / 2, dollW, height
+}
+
+// DollSlotAt retourne l'emplacement d'équipement sous le curseur
+func (inv *InventaireGUI) DollSlotAt(mx, my, screenW, screenH int) (EquipSlot, bool) {
+	x, y, w, _ := dollRect(screenW, screenH)
+	for i, slot := range EquipSlots {
+		slotY := y + 50 + i*dollSlotH
+		if mx >= x+10 && mx <= x+w-10 && my >= slotY && my <= slotY+dollSlotH-8 {
+			return slot, true
 		}
 	}
-	inv.keyPrevMouseLeft = mouseLeft
+	return "", false
+}
+
+// SlotAt retourne la case d'inventaire sous le curseur, ou -1
+func (inv *InventaireGUI) SlotAt(mx, my, screenW, screenH int) int {
+	return gridSlotAt(mx, my, screenW, screenH, len(inv.world.Player.Inventory), inv.Scroll)
+}
+
+// Met à jour l'inventaire ouvert (fermeture, utilisation des items)
+func (w *World) updateInventory(in Input) {
+	if in.ToggleInventory {
+		w.InventoryOpen = false
+		return
+	}
+
+	p := w.Player
+	if in.Unequip {
+		id := p.Equipment[in.EquipSlot]
+		if err := p.Desequiper(in.EquipSlot); err != nil {
+			w.InventoryMsg = w.say(err.Error())
+			return
+		}
+		w.InventoryMsg = w.say(fmt.Sprintf("%s retire %s.", p.Name, ItemName(id)))
+		return
+	}
+
+	if !in.UseItem {
+		return
+	}
+	if in.ItemSlot < 0 || in.ItemSlot >= len(p.Inventory) {
+		return
+	}
+	def, ok := DefaultItems().Item(p.Inventory[in.ItemSlot].ID)
+	if !ok {
+		return
+	}
+
+	// Armes et armures : équipées au lieu d'être consommées
+	if def.Slot != "" {
+		if err := p.Equiper(def.ID); err != nil {
+			w.InventoryMsg = w.say(err.Error())
+			return
+		}
+		w.InventoryMsg = w.say(fmt.Sprintf("%s équipe %s (%s).", p.Name, def.Name, def.Slot))
+		return
+	}
+
+	// Applique l'effet de l'item puis le retire
+	msg := p.UtiliserItem(def)
+	p.retirerCase(in.ItemSlot)
+	w.InventoryMsg = w.say(msg)
 }
 
 // Dessine l'inventaire à l'écran
 func (inv *InventaireGUI) Draw(screen *ebiten.Image) {
-	if !inv.open {
+	w := inv.world
+	if !w.InventoryOpen {
 		return
 	}
+	p := w.Player
 
 	screenW, screenH := screen.Size()
 	width, height := screenW*3/5, screenH*2/5
@@ -116,50 +237,112 @@ func (inv *InventaireGUI) Draw(screen *ebiten.Image) {
 	tW := text.BoundString(face, title).Dx()
 	text.Draw(screen, title, face, x+width/2-tW/2, y+30, color.RGBA{101, 67, 33, 255})
 
-	// Argent joueur
-	money := fmt.Sprintf("💰 Or: %d", inv.player.Money)
+	// Argent joueur et cases occupées
+	money := fmt.Sprintf("💰 Or: %d   Cases: %d/%d", p.Money, len(p.Inventory), p.Capacity)
 	tW = text.BoundString(face, money).Dx()
 	text.Draw(screen, money, face, x+width/2-tW/2, y+50, color.RGBA{139, 69, 19, 255})
 
+	inv.drawDoll(screen)
+
 	// Grille des items
-	colSize := 5
-	cellW, cellH := 110, 50
 	startX := x + 20
 	startY := y + 90
 	slotRadius := 10
 
-	if len(inv.player.Inventory) == 0 {
+	if len(p.Inventory) == 0 {
 		tW = text.BoundString(face, "(vide)").Dx()
 		text.Draw(screen, "(vide)", face, x+width/2-tW/2, startY, color.RGBA{101, 67, 33, 255})
 		return
 	}
 
-	for i, item := range inv.player.Inventory {
-		col := i % colSize
-		row := i / colSize
-		itemX := startX + col*cellW
-		itemY := startY + row*cellH
+	rows := gridRows(screenH)
+	inv.Scroll = gridScroll(inv.Scroll, inv.Focus, len(p.Inventory), rows, inv.ShowFocus && !inv.InDoll)
+	drawScrollMarks(screen, x+width-15, startY, inv.Scroll, len(p.Inventory), rows)
 
-		slotColor := color.RGBA{184, 134, 11, 200}
+	mx, my := ebiten.CursorPosition()
+	hover := gridSlotAt(mx, my, screenW, screenH, len(p.Inventory), inv.Scroll)
+	for i, stack := range p.Inventory {
+		if i/gridCols < inv.Scroll || i/gridCols >= inv.Scroll+rows {
+			continue
+		}
+		item := ItemName(stack.ID)
+		if stack.Count > 1 {
+			item = fmt.Sprintf("%s x%d", item, stack.Count)
+		}
+		itemX := startX + (i%gridCols)*gridCellW
+		itemY := startY + (i/gridCols-inv.Scroll)*gridCellH
 
-		mx, my := ebiten.CursorPosition()
-		if float64(mx) >= float64(itemX) && float64(mx) <= float64(itemX+cellW-10) &&
-			float64(my) >= float64(itemY) && float64(my) <= float64(itemY+cellH-10) {
+		slotColor := color.RGBA{184, 134, 11, 200}
+		if i == hover {
 			slotColor = color.RGBA{218, 165, 32, 230}
 		}
+		if inv.ShowFocus && !inv.InDoll && i == inv.Focus {
+			drawFocusRing(screen, itemX, itemY, slotRadius)
+		}
 
-		drawRoundedRect(screen, itemX, itemY, cellW-10, cellH-10, slotRadius, slotColor)
+		drawRoundedRect(screen, itemX, itemY, gridCellW-10, gridCellH-10, slotRadius, slotColor)
 
 		tW := text.BoundString(face, item).Dx()
 		tH := text.BoundString(face, item).Dy()
-		text.Draw(screen, item, face, itemX+(cellW-10)/2-tW/2, itemY+(cellH-10)/2+tH/2, color.RGBA{101, 67, 33, 255})
+		text.Draw(screen, item, face, itemX+(gridCellW-10)/2-tW/2, itemY+(gridCellH-10)/2+tH/2, color.RGBA{101, 67, 33, 255})
+	}
+
+	// Description de l'item survolé ou sélectionné
+	selected := hover
+	if selected < 0 && inv.ShowFocus && !inv.InDoll {
+		selected = inv.Focus
+	}
+	if selected >= 0 && selected < len(p.Inventory) {
+		if def, ok := DefaultItems().Item(p.Inventory[selected].ID); ok {
+			descW := text.BoundString(face, def.Description).Dx()
+			text.Draw(screen, def.Description, face, x+width/2-descW/2, y+height-40, color.RGBA{101, 67, 33, 255})
+		}
 	}
 
 	// Message temporaire
-	if inv.message != "" && time.Since(inv.msgTime).Seconds() < 2 {
-		msgW := text.BoundString(face, inv.message).Dx()
-		text.Draw(screen, inv.message, face, x+width/2-msgW/2, y+height-20, color.RGBA{255, 0, 0, 255})
+	if w.MessageVisible(w.InventoryMsg) {
+		msgW := text.BoundString(face, w.InventoryMsg.Text).Dx()
+		text.Draw(screen, w.InventoryMsg.Text, face, x+width/2-msgW/2, y+height-20, color.RGBA{255, 0, 0, 255})
+	}
+}
+
+// drawDoll dessine le panneau d'équipement : un emplacement par ligne
+func (inv *InventaireGUI) drawDoll(screen *ebiten.Image) {
+	p := inv.world.Player
+	screenW, screenH := screen.Size()
+	x, y, w, h := dollRect(screenW, screenH)
+	face := basicfont.Face7x13
+	brown := color.RGBA{101, 67, 33, 255}
+
+	drawRoundedRect(screen, x+5, y+5, w, h, 15, color.RGBA{120, 80, 30, 180})
+	drawRoundedRect(screen, x, y, w, h, 15, color.RGBA{210, 180, 140, 230})
+	title := "Équipement"
+	tW := text.BoundString(face, title).Dx()
+	text.Draw(screen, title, face, x+w/2-tW/2, y+30, brown)
+
+	mx, my := ebiten.CursorPosition()
+	hover, hovering := inv.DollSlotAt(mx, my, screenW, screenH)
+	for i, slot := range EquipSlots {
+		slotX, slotY := x+10, y+50+i*dollSlotH
+		slotColor := color.RGBA{184, 134, 11, 200}
+		if hovering && slot == hover {
+			slotColor = color.RGBA{218, 165, 32, 230}
+		}
+		if inv.ShowFocus && inv.InDoll && i == inv.DollFocus {
+			drawRoundedRect(screen, slotX-3, slotY-3, w-14, dollSlotH-2, 13, brown)
+		}
+		drawRoundedRect(screen, slotX, slotY, w-20, dollSlotH-8, 10, slotColor)
+
+		name := "-"
+		if id := p.Equipment[slot]; id != "" {
+			name = ItemName(id)
+		}
+		text.Draw(screen, slot.String()+" : "+name, face, slotX+10, slotY+(dollSlotH-8)/2+5, brown)
 	}
+
+	stats := fmt.Sprintf("Vie %d  Shield %d  Force %d", p.MaxLife, p.MaxShield, p.Strength)
+	text.Draw(screen, fmt.Sprintf("Défense %d", p.Defense), face, x+10, y+h-35, brown)
+	text.Draw(screen, stats, face, x+10, y+h-15, brown)
 }
 
 // Fonctions utilitaires graphiques
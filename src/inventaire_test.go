@@ -0,0 +1,67 @@
+package source
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestItemStacks(t *testing.T) {
+	p := nakedPlayer(t)
+	p.Capacity = 2
+
+	// 10 plantes par case : la onzième ouvre une deuxième case
+	for i := 0; i < 11; i++ {
+		if err := p.AjouterItem("plante_curative"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []ItemStack{{ID: "plante_curative", Count: 10}, {ID: "plante_curative", Count: 1}}
+	if !slices.Equal(p.Inventory, want) {
+		t.Fatalf("inventaire %v, attendu %v", p.Inventory, want)
+	}
+
+	// Les cases sont prises : seule la pile entamée accepte encore des plantes
+	if err := p.AjouterItem("potion_magique"); !errors.Is(err, ErrInventoryFull) {
+		t.Errorf("potion ajoutée à un inventaire plein : %v", err)
+	}
+	if !p.PeutAjouter("plante_curative") || p.PeutAjouter("epee") {
+		t.Error("place disponible mal calculée")
+	}
+	if !p.RetirerItem("plante_curative") || p.CompterItem("plante_curative") != 10 || len(p.Inventory) != 2 {
+		t.Errorf("après un retrait : %v", p.Inventory)
+	}
+}
+
+func TestShopCapacity(t *testing.T) {
+	w := NewWorldSeed(1)
+	p := w.Player
+	p.Inventory = nil
+	p.Money = 1000
+	w.ShopOpen = true
+	slot := func(id string) int {
+		return slices.IndexFunc(w.Shop.Items, func(d *ItemDef) bool { return d.ID == id })
+	}
+
+	for len(p.Inventory) < p.Capacity {
+		p.Inventory = append(p.Inventory, ItemStack{ID: "epee", Count: 1})
+	}
+	capacity := p.Capacity
+
+	// Inventaire plein : l'achat est refusé sans débiter l'or
+	w.Update(Input{BuyItem: true, ShopSlot: slot("potion_magique")})
+	if p.Money != 1000 || p.CompterItem("potion_magique") != 0 || !strings.Contains(w.ShopMsg.Text, "Inventaire plein") {
+		t.Fatalf("achat refusé : or %d, message %q", p.Money, w.ShopMsg.Text)
+	}
+
+	// Le sac agrandit l'inventaire sans prendre de case
+	w.Update(Input{BuyItem: true, ShopSlot: slot("sac")})
+	if p.Capacity != capacity+5 || p.CompterItem("sac") != 0 || p.Money != 900 {
+		t.Fatalf("sac acheté : %d cases, or %d", p.Capacity, p.Money)
+	}
+	w.Update(Input{BuyItem: true, ShopSlot: slot("potion_magique")})
+	if p.CompterItem("potion_magique") != 1 || p.Money != 875 {
+		t.Errorf("potion après le sac : %d, or %d", p.CompterItem("potion_magique"), p.Money)
+	}
+}
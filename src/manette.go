@@ -0,0 +1,231 @@
+package source
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// ----------------- Manettes -----------------
+// Les manettes sont lues via la disposition standard d'Ebiten : les boutons
+// sont nommés par leur position (RightBottom = A sur Xbox, B sur Nintendo).
+
+// stickDeadZone est l'inclinaison minimale du stick pour déclencher une action
+const stickDeadZone = 0.5
+
+// StickDirection associe une action à un sens d'inclinaison d'un axe
+type StickDirection struct {
+	Axis ebiten.StandardGamepadAxis // Axe du stick
+	Sign float64                    // -1 = gauche/haut, +1 = droite/bas
+}
+
+// GamepadLayout associe les actions aux boutons et sticks d'une manette
+type GamepadLayout struct {
+	Buttons map[Action][]ebiten.StandardGamepadButton
+	Sticks  map[Action][]StickDirection
+}
+
+// Noms des boutons et directions utilisables dans le fichier de contrôles
+var gamepadButtonNames = map[string]ebiten.StandardGamepadButton{
+	"RightBottom":      ebiten.StandardGamepadButtonRightBottom,
+	"RightRight":       ebiten.StandardGamepadButtonRightRight,
+	"RightLeft":        ebiten.StandardGamepadButtonRightLeft,
+	"RightTop":         ebiten.StandardGamepadButtonRightTop,
+	"FrontTopLeft":     ebiten.StandardGamepadButtonFrontTopLeft,
+	"FrontTopRight":    ebiten.StandardGamepadButtonFrontTopRight,
+	"FrontBottomLeft":  ebiten.StandardGamepadButtonFrontBottomLeft,
+	"FrontBottomRight": ebiten.StandardGamepadButtonFrontBottomRight,
+	"CenterLeft":       ebiten.StandardGamepadButtonCenterLeft,
+	"CenterRight":      ebiten.StandardGamepadButtonCenterRight,
+	"LeftStick":        ebiten.StandardGamepadButtonLeftStick,
+	"RightStick":       ebiten.StandardGamepadButtonRightStick,
+	"LeftTop":          ebiten.StandardGamepadButtonLeftTop,
+	"LeftBottom":       ebiten.StandardGamepadButtonLeftBottom,
+	"LeftLeft":         ebiten.StandardGamepadButtonLeftLeft,
+	"LeftRight":        ebiten.StandardGamepadButtonLeftRight,
+}
+
+var stickDirectionNames = map[string]StickDirection{
+	"LeftStickUp":     {ebiten.StandardGamepadAxisLeftStickVertical, -1},
+	"LeftStickDown":   {ebiten.StandardGamepadAxisLeftStickVertical, 1},
+	"LeftStickLeft":   {ebiten.StandardGamepadAxisLeftStickHorizontal, -1},
+	"LeftStickRight":  {ebiten.StandardGamepadAxisLeftStickHorizontal, 1},
+	"RightStickUp":    {ebiten.StandardGamepadAxisRightStickVertical, -1},
+	"RightStickDown":  {ebiten.StandardGamepadAxisRightStickVertical, 1},
+	"RightStickLeft":  {ebiten.StandardGamepadAxisRightStickHorizontal, -1},
+	"RightStickRight": {ebiten.StandardGamepadAxisRightStickHorizontal, 1},
+}
+
+// DefaultGamepadLayout retourne la disposition par défaut (type Xbox)
+func DefaultGamepadLayout() *GamepadLayout {
+	return &GamepadLayout{
+		Buttons: map[Action][]ebiten.StandardGamepadButton{
+			ActionAttack:          {ebiten.StandardGamepadButtonRightBottom},
+			ActionUseSword:        {ebiten.StandardGamepadButtonRightLeft},
+			ActionCombatItems:     {ebiten.StandardGamepadButtonRightTop},
+			ActionSkill1:          {ebiten.StandardGamepadButtonFrontTopLeft},
+			ActionSkill2:          {ebiten.StandardGamepadButtonFrontTopRight},
+			ActionSkill3:          {ebiten.StandardGamepadButtonLeftStick},
+			ActionSkill4:          {ebiten.StandardGamepadButtonRightStick},
+			ActionFlee:            {ebiten.StandardGamepadButtonRightRight},
+			ActionToggleInventory: {ebiten.StandardGamepadButtonCenterLeft},
+			ActionZoomIn:          {ebiten.StandardGamepadButtonFrontBottomRight},
+			ActionZoomOut:         {ebiten.StandardGamepadButtonFrontBottomLeft},
+			ActionNavUp:           {ebiten.StandardGamepadButtonLeftTop},
+			ActionNavDown:         {ebiten.StandardGamepadButtonLeftBottom},
+			ActionNavLeft:         {ebiten.StandardGamepadButtonLeftLeft},
+			ActionNavRight:        {ebiten.StandardGamepadButtonLeftRight},
+			ActionConfirm:         {ebiten.StandardGamepadButtonRightBottom},
+			ActionPause:           {ebiten.StandardGamepadButtonCenterRight},
+		},
+		Sticks: map[Action][]StickDirection{
+			ActionMoveUp:      {stickDirectionNames["LeftStickUp"]},
+			ActionMoveDown:    {stickDirectionNames["LeftStickDown"]},
+			ActionMoveLeft:    {stickDirectionNames["LeftStickLeft"]},
+			ActionMoveRight:   {stickDirectionNames["LeftStickRight"]},
+			ActionCameraUp:    {stickDirectionNames["RightStickUp"]},
+			ActionCameraDown:  {stickDirectionNames["RightStickDown"]},
+			ActionCameraLeft:  {stickDirectionNames["RightStickLeft"]},
+			ActionCameraRight: {stickDirectionNames["RightStickRight"]},
+		},
+	}
+}
+
+// nintendoGamepadLayout inverse les boutons de droite de la disposition
+// configurée pour garder "A = attaquer" et "B = fuir" sur les manettes Nintendo
+func nintendoGamepadLayout(base *GamepadLayout) *GamepadLayout {
+	l := base.clone()
+	swap := map[ebiten.StandardGamepadButton]ebiten.StandardGamepadButton{
+		ebiten.StandardGamepadButtonRightBottom: ebiten.StandardGamepadButtonRightRight,
+		ebiten.StandardGamepadButtonRightRight:  ebiten.StandardGamepadButtonRightBottom,
+		ebiten.StandardGamepadButtonRightLeft:   ebiten.StandardGamepadButtonRightTop,
+		ebiten.StandardGamepadButtonRightTop:    ebiten.StandardGamepadButtonRightLeft,
+	}
+	for a, buttons := range l.Buttons {
+		for i, b := range buttons {
+			if s, ok := swap[b]; ok {
+				l.Buttons[a][i] = s
+			}
+		}
+	}
+	return l
+}
+
+// clone copie la disposition pour pouvoir la modifier sans toucher l'originale
+func (l *GamepadLayout) clone() *GamepadLayout {
+	c := &GamepadLayout{
+		Buttons: map[Action][]ebiten.StandardGamepadButton{},
+		Sticks:  map[Action][]StickDirection{},
+	}
+	for a, b := range l.Buttons {
+		c.Buttons[a] = append([]ebiten.StandardGamepadButton(nil), b...)
+	}
+	for a, s := range l.Sticks {
+		c.Sticks[a] = append([]StickDirection(nil), s...)
+	}
+	return c
+}
+
+// apply remplace les boutons des actions présentes dans le fichier
+func (l *GamepadLayout) apply(bindings map[string][]string) error {
+	for name, inputs := range bindings {
+		a, err := ParseAction(name)
+		if err != nil {
+			return err
+		}
+		var buttons []ebiten.StandardGamepadButton
+		var sticks []StickDirection
+		for _, in := range inputs {
+			if b, ok := gamepadButtonNames[in]; ok {
+				buttons = append(buttons, b)
+			} else if s, ok := stickDirectionNames[in]; ok {
+				sticks = append(sticks, s)
+			} else {
+				return fmt.Errorf("bouton de manette inconnu : %q", in)
+			}
+		}
+		l.Buttons[a] = buttons
+		l.Sticks[a] = sticks
+	}
+	return nil
+}
+
+// pressed indique si l'action est déclenchée sur la manette id
+func (l *GamepadLayout) pressed(id ebiten.GamepadID, a Action) bool {
+	for _, b := range l.Buttons[a] {
+		if ebiten.IsStandardGamepadButtonPressed(id, b) {
+			return true
+		}
+	}
+	for _, s := range l.Sticks[a] {
+		if ebiten.StandardGamepadAxisValue(id, s.Axis)*s.Sign > stickDeadZone {
+			return true
+		}
+	}
+	return false
+}
+
+// Gamepads suit les manettes branchées et la disposition de chacune
+type Gamepads struct {
+	controls *Bindings                           // Dispositions configurées
+	layouts  map[ebiten.GamepadID]*GamepadLayout // Manettes connectées
+	newIDs   []ebiten.GamepadID
+}
+
+// NewGamepads crée le gestionnaire de manettes
+func NewGamepads(controls *Bindings) *Gamepads {
+	return &Gamepads{
+		controls: controls,
+		layouts:  map[ebiten.GamepadID]*GamepadLayout{},
+	}
+}
+
+// Update gère le branchement et le débranchement des manettes
+func (g *Gamepads) Update() {
+	g.newIDs = inpututil.AppendJustConnectedGamepadIDs(g.newIDs[:0])
+	for _, id := range g.newIDs {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			log.Printf("Manette non reconnue ignorée : %s", ebiten.GamepadName(id))
+			continue
+		}
+		g.layouts[id] = g.controls.gamepadLayout(ebiten.GamepadSDLID(id), ebiten.GamepadName(id))
+		log.Printf("Manette connectée : %s", ebiten.GamepadName(id))
+	}
+	for id := range g.layouts {
+		if inpututil.IsGamepadJustDisconnected(id) {
+			log.Printf("Manette déconnectée : %s", ebiten.GamepadName(id))
+			delete(g.layouts, id)
+		}
+	}
+}
+
+// Pressed indique si l'action est déclenchée sur une des manettes
+func (g *Gamepads) Pressed(a Action) bool {
+	for id, l := range g.layouts {
+		if l.pressed(id, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Connected indique si au moins une manette est utilisable
+func (g *Gamepads) Connected() bool {
+	return len(g.layouts) > 0
+}
+
+// gamepadLayout choisit la disposition d'une manette : profil propre à la
+// manette dans controles.json, sinon Nintendo ou disposition par défaut
+func (b *Bindings) gamepadLayout(sdlID, name string) *GamepadLayout {
+	if l, ok := b.GamepadProfiles[sdlID]; ok {
+		return l
+	}
+	lower := strings.ToLower(name)
+	if strings.Contains(lower, "nintendo") || strings.Contains(lower, "switch") {
+		return nintendoGamepadLayout(b.Gamepad)
+	}
+	return b.Gamepad
+}
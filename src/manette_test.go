@@ -0,0 +1,43 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestNintendoLayoutKeepsOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "controles.json")
+	data := `{"gamepad": {"Pause": ["FrontTopLeft"], "Flee": ["RightTop"]}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadBindingsFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := b.gamepadLayout("", "Nintendo Switch Pro Controller")
+	want := map[Action]ebiten.StandardGamepadButton{
+		ActionPause:  ebiten.StandardGamepadButtonFrontTopLeft, // Réglage du joueur, hors boutons inversés
+		ActionFlee:   ebiten.StandardGamepadButtonRightLeft,    // Réglage du joueur, inversé
+		ActionAttack: ebiten.StandardGamepadButtonRightRight,   // Défaut, inversé
+		ActionSkill1: ebiten.StandardGamepadButtonFrontTopLeft, // Défaut
+	}
+	for a, button := range want {
+		if !slices.Equal(l.Buttons[a], []ebiten.StandardGamepadButton{button}) {
+			t.Errorf("%s : %v, attendu %v", a, l.Buttons[a], button)
+		}
+	}
+
+	// La disposition configurée n'est pas modifiée
+	if got := b.Gamepad.Buttons[ActionFlee]; !slices.Equal(got, []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightTop}) {
+		t.Errorf("disposition de base modifiée : %v", got)
+	}
+	if b.gamepadLayout("", "Xbox Wireless Controller") != b.Gamepad {
+		t.Error("une manette Xbox devrait garder la disposition configurée")
+	}
+}
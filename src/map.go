@@ -12,12 +12,9 @@ import (
 
 // Variables globales pour la gestion de la map et du joueur
 var (
-	mapImage         *ebiten.Image             // Image de la map
-	playerX, playerY float64       = 1240, 600 // Position initiale du joueur
-
-	playerSpeed float64 = 3 // Vitesse du joueur
-	X, Y        float64     // Position de la caméra
-	Zoom        float64     // Zoom de la caméra
+	mapImage *ebiten.Image // Image de la map
+	X, Y     float64       // Position de la caméra
+	Zoom     float64       // Zoom de la caméra
 
 	// Sprites par direction
 	upSprites    []*ebiten.Image // Sprites pour déplacement haut
@@ -145,50 +142,44 @@ func loadImages(paths []string) []*ebiten.Image {
 	return imgs
 }
 
-func UpdatePlayer() {
-	moving := false
-
-	// Déplacement et direction
-	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyZ) {
-		playerY -= playerSpeed
+// AnimatePlayer choisit les sprites du joueur selon l'état de la partie
+func AnimatePlayer(w *World) {
+	switch w.PlayerDir {
+	case DirUp:
 		currentSprites = upSprites
-		moving = true
-	} else if ebiten.IsKeyPressed(ebiten.KeyS) {
-		playerY += playerSpeed
-		currentSprites = downSprites
-		moving = true
-	}
-	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyQ) {
-		playerX -= playerSpeed
+	case DirLeft:
 		currentSprites = leftSprites
-		moving = true
-	} else if ebiten.IsKeyPressed(ebiten.KeyD) {
-		playerX += playerSpeed
+	case DirRight:
 		currentSprites = rightSprites
-		moving = true
+	default:
+		currentSprites = downSprites
 	}
 
 	// Animation : avancer seulement si le personnage bouge
-	if moving && time.Since(lastUpdate) > 150*time.Millisecond {
+	if w.PlayerMoving && time.Since(lastUpdate) > 150*time.Millisecond {
 		index++
 		if index >= len(currentSprites) {
 			index = 0
 		}
 		lastUpdate = time.Now()
-	} else if !moving {
+	} else if !w.PlayerMoving {
 		// Reset sur la frame de repos quand il ne bouge pas
 		index = 0
 	}
+}
 
-	// Synchronisation avec la structure Personnage
-	if gameInstance != nil && gameInstance.player != nil {
-		gameInstance.player.PosX = playerX
-		gameInstance.player.PosY = playerY
+// currentPlayerImage retourne l'image actuelle du joueur
+func currentPlayerImage() *ebiten.Image {
+	if len(currentSprites) == 0 {
+		return nil
 	}
-
+	if index >= len(currentSprites) {
+		index = 0
+	}
+	return currentSprites[index]
 }
 
-func DrawMap(screen *ebiten.Image) {
+func DrawMap(screen *ebiten.Image, w *World) {
 
 	// Dessiner la map
 	if mapImage != nil {
@@ -209,14 +200,11 @@ func DrawMap(screen *ebiten.Image) {
 		screen.DrawImage(mapImage, op)
 	}
 
-	// Dessiner le personnage
-	if len(currentSprites) > 0 {
-		if index >= len(currentSprites) {
-			index = 0
-		}
+	// Dessiner le personnage (clignote pendant l'immunité après une fuite)
+	if img := currentPlayerImage(); img != nil && (!w.Immune() || w.Tick/8%2 == 0) {
 		opts := &ebiten.DrawImageOptions{}
-		opts.GeoM.Translate(playerX, playerY)
-		screen.DrawImage(currentSprites[index], opts)
+		opts.GeoM.Translate(w.Player.PosX, w.Player.PosY)
+		screen.DrawImage(img, opts)
 	}
 
 }
@@ -1,122 +1,113 @@
 package source
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font/basicfont"
 )
 
-// MenuMarchand gère l'interface du marchand
-// MenuMarchand gère l'interface du marchand et les interactions d'achat
-type MenuMarchand struct {
-	player      *Personnage // Référence au joueur
-	open        bool        // Menu ouvert ou fermé
-	shopItems   []ShopItem  // Liste des objets en vente
-	message     string      // Message temporaire
-	messageTime time.Time   // Temps d'affichage du message
-
-	shopZoneX        float64 // Position X du marchand
-	shopZoneY        float64 // Position Y du marchand
-	shopZoneW        float64 // Largeur de la zone du marchand
-	shopZoneH        float64 // Hauteur de la zone du marchand
-	lastMousePressed bool    // Pour détecter le front du clic
-}
-
-// ShopItem représente un objet à vendre
-// ShopItem représente un objet à vendre
-type ShopItem struct {
-	Name  string // Nom de l'objet
-	Price int    // Prix de l'objet
+// Marchand décrit le stand du marchand : sa zone sur la map et ses objets
+type Marchand struct {
+	X, Y  float64    // Position du marchand sur la map
+	W, H  float64    // Taille de la zone du marchand
+	Items []*ItemDef // Liste des objets en vente
 }
 
-// NewMenuMarchand initialise le marchand
-// Initialise le menu du marchand avec les objets disponibles
-func NewMenuMarchand(p *Personnage) *MenuMarchand {
-	items := []ShopItem{
-		{"Plante curative", 50},
-		{"Potion magique", 25},
-		{"Épée", 50},
-		{"Épée améliorée", 150},
-		{"Armure", 50},
-		{"Botte", 50},
-		{"Chapeau", 50},
-	}
-	return &MenuMarchand{
-		player:    p,
-		shopItems: items,
-		shopZoneX: 193, // coordonnées du marchand sur la map
-		shopZoneY: 9,
-		shopZoneW: 120, // largeur du sprite du marchand
-		shopZoneH: 120, // hauteur du sprite
+// NewMarchand initialise le marchand avec les objets de data/objets.json
+func NewMarchand() *Marchand {
+	return &Marchand{
+		Items: DefaultItems().ShopItems(),
+		X:     193, // coordonnées du marchand sur la map
+		Y:     9,
+		W:     120, // largeur du sprite du marchand
+		H:     120, // hauteur du sprite
 	}
 }
 
-// Update gère l'ouverture automatique et les achats
-// Met à jour l'état du menu marchand et gère les achats
-func (m *MenuMarchand) Update() {
-	playerX := m.player.PosX
-	playerY := m.player.PosY
-	playerW := m.player.Width
-	playerH := m.player.Height
+// checkShopZone ouvre le marchand quand le joueur entre dans sa zone
+func (w *World) checkShopZone() {
+	p := w.Player
+	m := w.Shop
 
 	// Détecte collision joueur <-> zone du marchand
-	if playerX < m.shopZoneX+m.shopZoneW &&
-		playerX+playerW > m.shopZoneX &&
-		playerY < m.shopZoneY+m.shopZoneH &&
-		playerY+playerH > m.shopZoneY {
-		m.open = true
-	} else {
-		m.open = false
+	inZone := overlaps(p.PosX, p.PosY, p.Width, p.Height, m.X, m.Y, m.W, m.H)
+	if inZone && !w.inShopZone {
+		w.ShopOpen = true
 	}
+	w.inShopZone = inZone
+}
 
-	if !m.open {
+// updateShop gère les achats et la fermeture du marchand
+func (w *World) updateShop(in Input) {
+	p := w.Player
+	m := w.Shop
+
+	if in.CloseShop {
+		w.ShopOpen = false
+		return
+	}
+	if !in.BuyItem {
+		return
+	}
+	if in.ShopSlot < 0 || in.ShopSlot >= len(m.Items) {
 		return
 	}
 
-	// Achat sur front du clic gauche
-	mousePressed := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
-	if mousePressed && !m.lastMousePressed {
-		mx, my := ebiten.CursorPosition()
-		colSize := 5
-		cellW, cellH := 110, 50
-		screenW, screenH := ebiten.ScreenSizeInFullscreen()
-		width, height := screenW*3/5, screenH*2/5
-		x := (screenW - width) / 2
-		y := (screenH - height) / 2
-		startX := x + 20
-		startY := y + 90
-
-		for i, item := range m.shopItems {
-			col := i % colSize
-			row := i / colSize
-			itemX := startX + col*cellW
-			itemY := startY + row*cellH
-
-			if float64(mx) >= float64(itemX) && float64(mx) <= float64(itemX+cellW-10) &&
-				float64(my) >= float64(itemY) && float64(my) <= float64(itemY+cellH-10) {
-
-				if m.player.Money >= item.Price {
-					m.player.Money -= item.Price
-					m.player.AjouterItem(item.Name) // applique effets automatiquement
-					m.message = fmt.Sprintf("Vous avez acheté %s pour %d pièces !", item.Name, item.Price)
-					m.messageTime = time.Now()
-				} else {
-					m.message = "Pas assez d'or !"
-					m.messageTime = time.Now()
-				}
+	item := m.Items[in.ShopSlot]
+	if p.Money >= item.Price {
+		// L'objet est rangé avant de payer : inventaire plein, rien n'est débité
+		if err := p.AjouterItem(item.ID); err != nil {
+			if errors.Is(err, ErrInventoryFull) {
+				w.ShopMsg = w.say("Inventaire plein ! Achetez un sac ou libérez une case.")
+				return
 			}
+			w.ShopMsg = w.say(err.Error())
+			return
 		}
+		p.Money -= item.Price
+		w.ShopMsg = w.say(fmt.Sprintf("Vous avez acheté %s pour %d pièces !", item.Name, item.Price))
+		w.checkpoint = true
+	} else {
+		w.ShopMsg = w.say("Pas assez d'or !")
 	}
-	m.lastMousePressed = mousePressed
+}
+
+// MenuMarchand gère l'affichage du menu du marchand
+type MenuMarchand struct {
+	world     *World // Partie affichée
+	Focus     int    // Objet sélectionné à la manette
+	ShowFocus bool   // Affiche l'objet sélectionné (manette branchée)
+	Scroll    int    // Première ligne affichée de la grille
+}
+
+// NewMenuMarchand crée le menu du marchand pour la partie
+func NewMenuMarchand(w *World) *MenuMarchand {
+	return &MenuMarchand{world: w}
+}
+
+// Navigate déplace l'objet sélectionné du marchand
+func (m *MenuMarchand) Navigate(dx, dy int) {
+	m.Focus = gridMove(m.Focus, dx, dy, len(m.world.Shop.Items))
+}
+
+// ScrollBy fait défiler la grille de quelques lignes (molette)
+func (m *MenuMarchand) ScrollBy(rows int) {
+	m.Scroll += rows
+}
+
+// SlotAt retourne l'objet du marchand sous le curseur, ou -1
+func (m *MenuMarchand) SlotAt(mx, my, screenW, screenH int) int {
+	return gridSlotAt(mx, my, screenW, screenH, len(m.world.Shop.Items), m.Scroll)
 }
 
 // Draw affiche le menu marchand
 func (m *MenuMarchand) Draw(screen *ebiten.Image) {
-	if !m.open {
+	w := m.world
+	if !w.ShopOpen {
 		return
 	}
 
@@ -138,41 +129,58 @@ func (m *MenuMarchand) Draw(screen *ebiten.Image) {
 	text.Draw(screen, title, face, x+width/2-tW/2, y+30, color.RGBA{101, 67, 33, 255})
 
 	// Argent joueur
-	money := fmt.Sprintf("💰 Or: %d", m.player.Money)
+	money := fmt.Sprintf("💰 Or: %d", w.Player.Money)
 	tW = text.BoundString(face, money).Dx()
 	text.Draw(screen, money, face, x+width/2-tW/2, y+50, color.RGBA{139, 69, 19, 255})
 
 	// Affiche les items
-	colSize := 5
-	cellW, cellH := 110, 50
 	startX := x + 20
 	startY := y + 90
 	slotRadius := 10
 
-	for i, item := range m.shopItems {
-		col := i % colSize
-		row := i / colSize
-		itemX := startX + col*cellW
-		itemY := startY + row*cellH
+	rows := gridRows(screenH)
+	m.Scroll = gridScroll(m.Scroll, m.Focus, len(w.Shop.Items), rows, m.ShowFocus)
+	drawScrollMarks(screen, x+width-15, startY, m.Scroll, len(w.Shop.Items), rows)
+
+	mx, my := ebiten.CursorPosition()
+	hover := gridSlotAt(mx, my, screenW, screenH, len(w.Shop.Items), m.Scroll)
+	for i, item := range w.Shop.Items {
+		if i/gridCols < m.Scroll || i/gridCols >= m.Scroll+rows {
+			continue
+		}
+		itemX := startX + (i%gridCols)*gridCellW
+		itemY := startY + (i/gridCols-m.Scroll)*gridCellH
 
 		slotColor := color.RGBA{184, 134, 11, 200}
-		mx, my := ebiten.CursorPosition()
-		if float64(mx) >= float64(itemX) && float64(mx) <= float64(itemX+cellW-10) &&
-			float64(my) >= float64(itemY) && float64(my) <= float64(itemY+cellH-10) {
+		if i == hover {
 			slotColor = color.RGBA{218, 165, 32, 230}
 		}
+		if m.ShowFocus && i == m.Focus {
+			drawFocusRing(screen, itemX, itemY, slotRadius)
+		}
 
-		drawRoundedRect(screen, itemX, itemY, cellW-10, cellH-10, slotRadius, slotColor)
+		drawRoundedRect(screen, itemX, itemY, gridCellW-10, gridCellH-10, slotRadius, slotColor)
 
 		textStr := fmt.Sprintf("%s (%d)", item.Name, item.Price)
 		tW := text.BoundString(face, textStr).Dx()
 		tH := text.BoundString(face, textStr).Dy()
-		text.Draw(screen, textStr, face, itemX+(cellW-10)/2-tW/2, itemY+(cellH-10)/2+tH/2, color.RGBA{101, 67, 33, 255})
+		text.Draw(screen, textStr, face, itemX+(gridCellW-10)/2-tW/2, itemY+(gridCellH-10)/2+tH/2, color.RGBA{101, 67, 33, 255})
+	}
+
+	// Description de l'objet survolé ou sélectionné
+	selected := hover
+	if selected < 0 && m.ShowFocus {
+		selected = m.Focus
+	}
+	if selected >= 0 && selected < len(w.Shop.Items) {
+		desc := w.Shop.Items[selected].Description
+		descW := text.BoundString(face, desc).Dx()
+		text.Draw(screen, desc, face, x+width/2-descW/2, y+height-40, color.RGBA{101, 67, 33, 255})
 	}
 
 	// Message achat ou erreur
-	if m.message != "" && time.Since(m.messageTime).Seconds() < 2 {
-		msgW := text.BoundString(face, m.message).Dx()
-		text.Draw(screen, m.message, face, x+width/2-msgW/2, y+height-20, color.RGBA{255, 0, 0, 255})
+	if w.MessageVisible(w.ShopMsg) {
+		msgW := text.BoundString(face, w.ShopMsg.Text).Dx()
+		text.Draw(screen, w.ShopMsg.Text, face, x+width/2-msgW/2, y+height-20, color.RGBA{255, 0, 0, 255})
 	}
 }
@@ -0,0 +1,409 @@
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// ----------------- Migrations des sauvegardes -----------------
+// Chaque changement du format de sauvegarde incrémente SaveVersion et ajoute
+// ici une migration qui transforme une sauvegarde de la version N en N+1.
+// Les migrations travaillent sur le JSON brut : les anciennes structures Go
+// n'existent plus. Une vieille sauvegarde passe par toutes les étapes.
+// Les données du jeu utiles à une migration (noms, dégâts, vie des
+// monstres...) y sont recopiées telles qu'elles étaient à cette version :
+// modifier src/data ne change pas la lecture des anciennes sauvegardes.
+//
+// Historique :
+//   v1 : état de la partie à la racine du fichier (tick, player, monsters...)
+//   v2 : état de la partie regroupé sous "world", à côté de l'en-tête
+//   v3 : monstres décrits par le bestiaire (SpritePaths, Reward, Behaviour)
+//   v4 : inventaire en cases {ID, Count} au lieu d'une liste de noms
+//   v5 : plus de Reward sur les monstres (butin lu dans le bestiaire)
+//   v6 : graine et état des flux aléatoires ("rng")
+//   v7 : niveau, expérience et points de statistique du joueur
+//   v8 : équipement porté et statistiques de base du joueur
+//   v9 : nombre de cases de l'inventaire et taille maximum des piles
+//   v10 : vie maximum, défense et résistance gardées sur chaque monstre
+//   v11 : IA des monstres (point de départ, état, destination)
+//   v12 : zones d'apparition (zone de chaque monstre, délais en cours)
+
+// Erreurs de lecture des sauvegardes
+var (
+	ErrSaveCorrupted = errors.New("sauvegarde corrompue")
+	ErrSaveTooNew    = errors.New("sauvegarde créée par une version plus récente du jeu")
+	ErrSaveVersion   = errors.New("version de sauvegarde non supportée")
+)
+
+// rawSave est une sauvegarde JSON décodée sans structure
+type rawSave = map[string]any
+
+// saveMigrations associe à chaque version la fonction qui la met à jour
+var saveMigrations = map[int]func(rawSave) error{
+	1:  migrateSaveV1,
+	2:  migrateSaveV2,
+	3:  migrateSaveV3,
+	4:  migrateSaveV4,
+	5:  migrateSaveV5,
+	6:  migrateSaveV6,
+	7:  migrateSaveV7,
+	8:  migrateSaveV8,
+	9:  migrateSaveV9,
+	10: migrateSaveV10,
+	11: migrateSaveV11,
+}
+
+// DecodeSave décode une sauvegarde et la met à jour vers SaveVersion
+func DecodeSave(data []byte) (*SaveData, error) {
+	var raw rawSave
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w : %v", ErrSaveCorrupted, err)
+	}
+	if err := migrateSave(raw); err != nil {
+		return nil, err
+	}
+
+	// Relit la sauvegarde à jour dans les structures actuelles
+	upgraded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w : %v", ErrSaveCorrupted, err)
+	}
+	var d SaveData
+	if err := json.Unmarshal(upgraded, &d); err != nil {
+		return nil, fmt.Errorf("%w : %v", ErrSaveCorrupted, err)
+	}
+	return &d, nil
+}
+
+// migrateSave applique les migrations une à une jusqu'à SaveVersion
+func migrateSave(raw rawSave) error {
+	version, err := saveVersion(raw)
+	if err != nil {
+		return err
+	}
+	if version > SaveVersion {
+		return fmt.Errorf("%w (version %d, ce jeu lit jusqu'à la version %d)", ErrSaveTooNew, version, SaveVersion)
+	}
+	for version < SaveVersion {
+		migrate, ok := saveMigrations[version]
+		if !ok {
+			return fmt.Errorf("%w : version %d", ErrSaveVersion, version)
+		}
+		if err := migrate(raw); err != nil {
+			return fmt.Errorf("%w : migration depuis la version %d : %w", ErrSaveCorrupted, version, err)
+		}
+		version++
+		raw["version"] = version
+	}
+	return nil
+}
+
+// saveVersion lit l'en-tête de version d'une sauvegarde
+func saveVersion(raw rawSave) (int, error) {
+	v, ok := raw["version"].(float64)
+	if !ok || v < 1 || v != float64(int(v)) {
+		return 0, fmt.Errorf("%w : en-tête de version absent ou invalide", ErrSaveCorrupted)
+	}
+	return int(v), nil
+}
+
+// migrateSaveV1 regroupe l'état de la partie sous "world"
+func migrateSaveV1(raw rawSave) error {
+	world := rawSave{}
+	for _, key := range []string{"tick", "player", "player_dir", "monsters"} {
+		if v, ok := raw[key]; ok {
+			world[key] = v
+			delete(raw, key)
+		}
+	}
+	if _, ok := world["player"]; !ok {
+		return errors.New("joueur absent")
+	}
+	raw["world"] = world
+	return nil
+}
+
+// migrateSaveV2 complète les monstres avec les champs du bestiaire. En v3,
+// tous les monstres se promenaient.
+func migrateSaveV2(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	monsters, _ := world["monsters"].([]any)
+	for _, v := range monsters {
+		m, ok := v.(rawSave)
+		if !ok {
+			return errors.New("monstre invalide")
+		}
+		if path, ok := m["SpritePath"].(string); ok && path != "" {
+			m["SpritePaths"] = []any{path}
+		}
+		delete(m, "SpritePath")
+		m["Behaviour"] = "wander"
+	}
+	return nil
+}
+
+// v3Items donne l'identifiant des objets des inventaires v3 (par leur nom
+// affiché) et s'ils s'empilent en v4
+var v3Items = map[string]struct {
+	ID        string
+	Stackable bool
+}{
+	"Plante curative": {"plante_curative", true},
+	"Potion magique":  {"potion_magique", true},
+	"Épée":            {"epee", false},
+	"Épée améliorée":  {"epee_amelioree", false},
+	"Armure":          {"armure", false},
+	"Botte":           {"botte", false},
+	"Chapeau":         {"chapeau", false},
+}
+
+// migrateSaveV3 remplace les noms d'objets de l'inventaire par leur identifiant
+func migrateSaveV3(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	player, ok := world["player"].(rawSave)
+	if !ok {
+		return errors.New("joueur absent")
+	}
+	names, _ := player["Inventory"].([]any)
+
+	stacks := []any{}
+	index := map[string]rawSave{} // Case des objets empilables
+	for _, v := range names {
+		name, _ := v.(string)
+		def, ok := v3Items[name]
+		if !ok {
+			return fmt.Errorf("%w : %q", ErrUnknownItem, name)
+		}
+		if s, ok := index[def.ID]; ok {
+			s["Count"] = s["Count"].(int) + 1
+			continue
+		}
+		s := rawSave{"ID": def.ID, "Count": 1}
+		if def.Stackable {
+			index[def.ID] = s
+		}
+		stacks = append(stacks, s)
+	}
+	player["Inventory"] = stacks
+	return nil
+}
+
+// migrateSaveV4 retire la récompense fixe des monstres
+func migrateSaveV4(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	monsters, _ := world["monsters"].([]any)
+	for _, v := range monsters {
+		if m, ok := v.(rawSave); ok {
+			delete(m, "Reward")
+		}
+	}
+	return nil
+}
+
+// migrateSaveV5 donne une graine aux parties qui n'en avaient pas. La
+// graine vient du contenu de la sauvegarde (tick et position du joueur) :
+// la même sauvegarde donne toujours la même partie.
+func migrateSaveV5(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	player, _ := world["player"].(rawSave)
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v/%v/%v", world["tick"], player["PosX"], player["PosY"])
+	world["rng"] = NewRNG(h.Sum64()).State()
+	return nil
+}
+
+// migrateSaveV6 place les anciens joueurs au niveau 1
+func migrateSaveV6(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	player, ok := world["player"].(rawSave)
+	if !ok {
+		return errors.New("joueur absent")
+	}
+	player["Level"] = 1
+	player["XP"] = 0
+	player["StatPoints"] = 0
+	return nil
+}
+
+// v7WeaponDamage donne les dégâts des armes des inventaires v7
+var v7WeaponDamage = map[string]int{
+	"epee":           40,
+	"epee_amelioree": 75,
+}
+
+// migrateSaveV7 sépare les statistiques de base et équipe la meilleure arme
+// de l'inventaire (avant la v8, elle était utilisée automatiquement)
+func migrateSaveV7(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	player, ok := world["player"].(rawSave)
+	if !ok {
+		return errors.New("joueur absent")
+	}
+	player["Base"] = rawSave{
+		"MaxLife":   player["MaxLife"],
+		"MaxShield": player["MaxShield"],
+		"Strength":  player["Strength"],
+	}
+
+	equipment := rawSave{}
+	stacks, _ := player["Inventory"].([]any)
+	best, bestDamage := -1, 0
+	for i, v := range stacks {
+		s, _ := v.(rawSave)
+		id, _ := s["ID"].(string)
+		if dmg := v7WeaponDamage[id]; dmg > bestDamage {
+			best, bestDamage = i, dmg
+		}
+	}
+	if best >= 0 {
+		equipment[string(SlotWeapon)] = stacks[best].(rawSave)["ID"]
+		player["Inventory"] = append(stacks[:best:best], stacks[best+1:]...)
+	}
+	player["Equipment"] = equipment
+	return nil
+}
+
+// v8StackLimits donne la taille maximum des piles en v9 (1 pour les objets
+// qui ne s'empilent pas)
+var v8StackLimits = map[string]int{
+	"plante_curative": 10,
+	"potion_magique":  10,
+	"epee":            1,
+	"epee_amelioree":  1,
+	"armure":          1,
+	"botte":           1,
+	"chapeau":         1,
+	"amulette":        1,
+}
+
+// migrateSaveV8 découpe les piles trop grandes et donne à l'inventaire
+// assez de cases pour tout garder
+func migrateSaveV8(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	player, ok := world["player"].(rawSave)
+	if !ok {
+		return errors.New("joueur absent")
+	}
+	stacks, _ := player["Inventory"].([]any)
+	inventory := []any{}
+	for _, v := range stacks {
+		s, _ := v.(rawSave)
+		id, _ := s["ID"].(string)
+		count := rawInt(s["Count"])
+		limit, ok := v8StackLimits[id]
+		if !ok {
+			inventory = append(inventory, v) // Refusé au chargement
+			continue
+		}
+		for n := count; n > 0; n -= limit {
+			inventory = append(inventory, rawSave{"ID": id, "Count": min(n, limit)})
+		}
+	}
+	player["Inventory"] = inventory
+	player["Capacity"] = max(DefaultCapacity, len(inventory))
+	return nil
+}
+
+// v9Monsters donne la vie maximum, la défense et la résistance des monstres
+// du bestiaire en v10
+var v9Monsters = map[string]struct {
+	Health     int
+	Defense    int
+	Resistance float64
+}{
+	"Serpent":  {200, 0, 0.1},
+	"Scorpion": {100, 2, 0},
+	"Hyène":    {400, 4, 0},
+}
+
+// migrateSaveV9 copie sur chaque monstre sa vie maximum, sa défense et sa
+// résistance, telles qu'elles étaient dans le bestiaire. Un monstre
+// inconnu garde sa vie actuelle comme maximum.
+func migrateSaveV9(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	monsters, _ := world["monsters"].([]any)
+	for _, v := range monsters {
+		m, ok := v.(rawSave)
+		if !ok {
+			continue
+		}
+		name, _ := m["Name"].(string)
+		health := rawInt(m["Health"])
+		m["MaxHealth"] = health
+		if def, ok := v9Monsters[name]; ok {
+			m["MaxHealth"] = max(def.Health, health)
+			m["Defense"] = def.Defense
+			m["Resistance"] = def.Resistance
+		}
+	}
+	return nil
+}
+
+// migrateSaveV10 prend la position actuelle de chaque monstre comme point
+// de départ de son IA
+func migrateSaveV10(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	monsters, _ := world["monsters"].([]any)
+	for _, v := range monsters {
+		m, ok := v.(rawSave)
+		if !ok {
+			continue
+		}
+		m["HomeX"], m["HomeY"] = m["X"], m["Y"]
+		m["TargetX"], m["TargetY"] = m["X"], m["Y"]
+	}
+	return nil
+}
+
+// migrateSaveV11 ajoute les délais des zones d'apparition. Les monstres
+// déjà présents n'appartiennent à aucune zone : les zones se remplissent
+// au fil de la partie.
+func migrateSaveV11(raw rawSave) error {
+	world, ok := raw["world"].(rawSave)
+	if !ok {
+		return errors.New("partie absente")
+	}
+	world["spawns"] = rawSave{}
+	return nil
+}
+
+// rawInt lit un nombre entier : décodé du JSON (float64) ou ajouté par
+// une migration précédente (int)
+func rawInt(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
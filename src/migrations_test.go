@@ -0,0 +1,153 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Les fichiers testdata/save_vN.json sont de vraies sauvegardes écrites par
+// le jeu en version N : la même partie (tick 321, joueur en 900,420 avec 80
+// PV et 250 pièces, 12 plantes, une potion, deux épées et une armure,
+// serpent blessé) dans chaque format. À partir de la v8, l'épée améliorée
+// et l'armure sont équipées.
+
+// Inventaire attendu après migration
+var (
+	// Avant la v8, la meilleure arme est équipée par la migration ; l'armure
+	// reste dans l'inventaire
+	oldInventory = []ItemStack{{"plante_curative", 10}, {"plante_curative", 2}, {"potion_magique", 1}, {"epee", 1}, {"armure", 1}}
+	newInventory = []ItemStack{{"plante_curative", 10}, {"plante_curative", 2}, {"potion_magique", 1}, {"epee", 1}}
+)
+
+func TestSaveMigrations(t *testing.T) {
+	for version := 1; version < SaveVersion; version++ {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", fmt.Sprintf("save_v%d.json", version)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := DecodeSave(data)
+			if err != nil {
+				t.Fatalf("DecodeSave : %v", err)
+			}
+			if d.Version != SaveVersion {
+				t.Errorf("version %d, attendue %d", d.Version, SaveVersion)
+			}
+			w, err := WorldFromSave(d)
+			if err != nil {
+				t.Fatalf("WorldFromSave : %v", err)
+			}
+
+			p := w.Player
+			if w.Tick != 321 || p.PosX != 900 || p.PosY != 420 || p.Life != 80 || p.Money != 250 {
+				t.Errorf("partie : tick %d, position %v,%v, vie %d, or %d", w.Tick, p.PosX, p.PosY, p.Life, p.Money)
+			}
+			inventory, equipment, maxShield := oldInventory, map[EquipSlot]string{SlotWeapon: "epee_amelioree"}, 100
+			if version >= 8 {
+				inventory, equipment, maxShield = newInventory, map[EquipSlot]string{SlotWeapon: "epee_amelioree", SlotBody: "armure"}, 130
+			}
+			if !slices.Equal(p.Inventory, inventory) {
+				t.Errorf("inventaire %v, attendu %v", p.Inventory, inventory)
+			}
+			if !maps.Equal(p.Equipment, equipment) {
+				t.Errorf("équipement %v, attendu %v", p.Equipment, equipment)
+			}
+			if p.MaxShield != maxShield {
+				t.Errorf("shield maximum %d, attendu %d", p.MaxShield, maxShield)
+			}
+			if p.Capacity != DefaultCapacity || p.Level != 1 {
+				t.Errorf("capacité %d, niveau %d", p.Capacity, p.Level)
+			}
+
+			if len(w.Monsters) != 3 {
+				t.Fatalf("%d monstres, attendus 3", len(w.Monsters))
+			}
+			m := w.Monsters[0]
+			if m.Name != "Serpent" || m.Health != 150 || m.MaxHealth != 200 {
+				t.Errorf("monstre %s : %d/%d PV, attendu Serpent 150/200", m.Name, m.Health, m.MaxHealth)
+			}
+			if m.HomeX == 0 && m.HomeY == 0 {
+				t.Error("point de départ du monstre absent")
+			}
+
+			// La migration est reproductible : même sauvegarde, même graine
+			again, err := DecodeSave(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again.World.RNG.Seed != d.World.RNG.Seed {
+				t.Errorf("graine différente à chaque lecture : %d puis %d", d.World.RNG.Seed, again.World.RNG.Seed)
+			}
+		})
+	}
+}
+
+func TestSaveMigrationsFrozenData(t *testing.T) {
+	// Données du jeu modifiées depuis : objet renommé, arme et monstre
+	// changés, piles plus petites
+	data, _ := dataFiles.ReadFile("data/objets.json")
+	items, err := ParseItems("objets.json", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sword, _ := items.Item("epee")
+	sword.Name = "Lame rouillée"
+	sword.Effects = []ItemEffect{{Type: EffectWeaponDamage, Amount: 500}}
+	plant, _ := items.Item("plante_curative")
+	plant.MaxStack = 3
+	data, _ = dataFiles.ReadFile("data/monstres.json")
+	bestiary, err := ParseBestiary("monstres.json", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snake, _ := bestiary.Def("Serpent")
+	snake.Health, snake.Defense, snake.Behaviour = 900, 9, BehaviourStatic
+	swapData(t, &itemsData, items)
+	swapData(t, &bestiaryData, bestiary)
+
+	// Une vieille sauvegarde est lue comme avec les données de l'époque
+	data, err = os.ReadFile(filepath.Join("testdata", "save_v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := DecodeSave(data)
+	if err != nil {
+		t.Fatalf("DecodeSave : %v", err)
+	}
+	p := d.World.Player
+	if !slices.Equal(p.Inventory, oldInventory) || p.Equipment[SlotWeapon] != "epee_amelioree" {
+		t.Errorf("inventaire %v, équipement %v", p.Inventory, p.Equipment)
+	}
+	m := d.World.Monsters[0]
+	if m.MaxHealth != 200 || m.Defense != 0 || m.Behaviour != BehaviourWander {
+		t.Errorf("serpent : %d PV max, défense %d, comportement %s", m.MaxHealth, m.Defense, m.Behaviour)
+	}
+}
+
+func TestSaveErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"trop récente", fmt.Sprintf(`{"version": %d, "world": {}}`, SaveVersion+1), ErrSaveTooNew},
+		{"JSON invalide", `{"version": 3, "world": `, ErrSaveCorrupted},
+		{"sans version", `{"world": {}}`, ErrSaveCorrupted},
+		{"version invalide", `{"version": 1.5}`, ErrSaveCorrupted},
+		{"joueur absent", `{"version": 1, "tick": 3}`, ErrSaveCorrupted},
+		{"objet inconnu", `{"version": 3, "world": {"player": {"Inventory": ["Tapis volant"]}}}`, ErrSaveCorrupted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeSave([]byte(tt.data))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("erreur %v, attendue %v", err, tt.err)
+			}
+		})
+	}
+}
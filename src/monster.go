@@ -2,7 +2,6 @@ package source
 
 import (
 	"image/color"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
@@ -12,143 +11,77 @@ import (
 // ----------------- Structure Monstre -----------------
 // Monster représente un monstre sur la map
 type Monster struct {
-	Name       string          // Nom du monstre
-	X, Y       float64         // Position
-	Sprites    []*ebiten.Image // Images pour l'animation
-	Index      int             // Frame actuelle
-	LastUpdate time.Time       // Dernière mise à jour animation
-	Speed      float64         // Vitesse du monstre
-	DirX, DirY float64         // Direction du mouvement
-	Health     int             // Points de vie du monstre
-	Damage     int
+	Name             string          // Nom du monstre (type dans le bestiaire)
+	X, Y             float64         // Position
+	W, H             float64         // Taille de la zone de collision
+	SpritePaths      []string        // Images de l'animation
+	Scale            float64         // Facteur d'échelle du sprite
+	Sprites          []*ebiten.Image `json:"-"` // Images pour l'animation (nil sans affichage)
+	Speed            float64         // Vitesse du monstre
+	DirX, DirY       float64         // Direction du mouvement
+	HomeX, HomeY     float64         // Point de départ : centre de la promenade ou poste de garde
+	AI               AIState         // État de l'IA (AIWander...)
+	TargetX, TargetY float64         // Destination de la promenade
+	AITick           int             // Tick du prochain choix de destination
+	Health           int             // Points de vie du monstre (gardés entre deux combats)
+	MaxHealth        int             // Points de vie maximum
+	Damage           int             // Dégâts de base d'une attaque
+	Defense          int             // Dégâts retirés à chaque coup reçu
+	Resistance       float64         // Part des dégâts ignorée (0 à 0,9)
+	Behaviour        string          // Comportement sur la map (BehaviourWander...)
+	Zone             string          // Zone d'apparition ("" : placé au début de la partie)
+	Statuts          StatusList      `json:"-"` // Effets de statut actifs (combat seulement)
+	Bonus            Modifiers       `json:"-"` // Bonus des effets actifs (rage)
 }
 
-// Liste des monstres
-// Liste des monstres présents sur la map
-var monsters []*Monster
-
-// Message affiché lors d'un combat
-var combatMessage string
-
 // Police par défaut pour les messages de combat
 var combatFont = basicfont.Face7x13
 
 // ----------------- Initialisation des monstres -----------------
-// Initialise les monstres sur la map
-func InitMonsters() {
-	serpent := &Monster{
-		Name:       "Serpent",
-		X:          1300,
-		Y:          75,
-		Sprites:    loadAndScale([]string{"src/assets/serpent1.png"}, 0.07),
-		Speed:      1.5,
-		LastUpdate: time.Now(),
-		Health:     200,
-		Damage:     15,
-	}
-
-	scorpion := &Monster{
-		Name:       "Scorpion",
-		X:          220,
-		Y:          350,
-		Sprites:    loadAndScale([]string{"src/assets/scorpion1.png"}, 0.20),
-		Speed:      2,
-		LastUpdate: time.Now(),
-		Health:     100,
-		Damage:     5,
-	}
+// Crée les monstres de la map décrits dans le bestiaire (sans charger leurs images)
+func InitMonsters() []*Monster {
+	return DefaultBestiary().Spawn()
+}
 
-	hyene := &Monster{
-		Name:       "Hyène",
-		X:          350,
-		Y:          650,
-		Sprites:    loadAndScale([]string{"src/assets/hyene1.png"}, 0.20),
-		Speed:      1,
-		LastUpdate: time.Now(),
-		Health:     400,
-		Damage:     25,
+// LoadMonsterSprites charge les images des monstres pour l'affichage
+func LoadMonsterSprites(ms []*Monster) {
+	for _, m := range ms {
+		if m.Sprites == nil && len(m.SpritePaths) > 0 {
+			m.Sprites = loadAndScale(m.SpritePaths, m.Scale)
+		}
 	}
-
-	monsters = []*Monster{serpent, scorpion, hyene}
 }
 
 // ----------------- Mise à jour des monstres -----------------
-// Met à jour la position et l'état des monstres
-func UpdateMonsters() {
-	for _, m := range monsters {
-		// Déplacement
-		m.X += m.DirX * m.Speed
-		m.Y += m.DirY * m.Speed
-
-		// Rebondir sur les bords de la map
-		if m.X < 0 || m.X > 1920 {
-			m.DirX *= -1
-		}
-		if m.Y < 0 || m.Y > 1080 {
-			m.DirY *= -1
-		}
-
-		// Animation
-		if len(m.Sprites) > 1 && time.Since(m.LastUpdate) > 200*time.Millisecond {
-			m.Index++
-			if m.Index >= len(m.Sprites) {
-				m.Index = 0
-			}
-			m.LastUpdate = time.Now()
-		}
+// Met à jour la position des monstres
+func (w *World) updateMonsters() {
+	for _, m := range w.Monsters {
+		w.updateMonsterAI(m)
 	}
-
-	// ...collision combat gérée ailleurs...
 }
 
 // ----------------- Dessin des monstres -----------------
+// monsterFrame choisit l'image d'animation d'un monstre pour le tick courant
+func monsterFrame(m *Monster, tick int) *ebiten.Image {
+	if len(m.Sprites) == 0 {
+		return nil
+	}
+	// Une image toutes les 200 ms
+	return m.Sprites[(tick/(TicksPerSecond/5))%len(m.Sprites)]
+}
+
 // Dessine les monstres à l'écran
-func DrawMonsters(screen *ebiten.Image) {
-	for _, m := range monsters {
-		if len(m.Sprites) > 0 {
+func DrawMonsters(screen *ebiten.Image, w *World) {
+	for _, m := range w.Monsters {
+		LoadMonsterSprites([]*Monster{m})
+		if img := monsterFrame(m, w.Tick); img != nil {
 			opts := &ebiten.DrawImageOptions{}
 			opts.GeoM.Translate(m.X, m.Y)
-			screen.DrawImage(m.Sprites[m.Index%len(m.Sprites)], opts)
+			screen.DrawImage(img, opts)
+		}
+		// Le monstre a repéré le joueur
+		if m.AI == AIChase {
+			text.Draw(screen, "!", combatFont, int(m.X+m.W/2), int(m.Y)-4, color.RGBA{255, 0, 0, 255})
 		}
 	}
 }
-
-// ----------------- Détection des collisions -----------------
-// Vérifie la collision entre le joueur et les monstres
-// ...fonction inutile supprimée...
-
-// ----------------- Dessin de la fenêtre combat -----------------
-// Dessine le message de combat à l'écran
-func DrawCombatMessage(screen *ebiten.Image) {
-	if combatMessage == "" {
-		return
-	}
-
-	screenW, screenH := screen.Size()
-	winW, winH := 700, 250
-	x := (screenW - winW) / 2
-	y := (screenH - winH) / 2
-
-	// Fenêtre semi-transparente couleur désert
-	win := ebiten.NewImage(winW, winH)
-	win.Fill(color.RGBA{237, 201, 175, 200}) // sable clair semi-transparent
-	opts := &ebiten.DrawImageOptions{}
-	opts.GeoM.Translate(float64(x), float64(y))
-	screen.DrawImage(win, opts)
-
-	// Bordure marron foncé
-	border := ebiten.NewImage(winW, winH)
-	border.Fill(color.RGBA{139, 69, 19, 255}) // marron
-	borderOpts := &ebiten.DrawImageOptions{}
-	borderOpts.GeoM.Translate(float64(x-4), float64(y-4))
-	screen.DrawImage(border, borderOpts)
-
-	// Texte centré en rouge
-	bounds := text.BoundString(combatFont, combatMessage)
-	textW := bounds.Dx()
-	textH := bounds.Dy()
-	tx := x + (winW-textW)/2
-	ty := y + (winH-textH)/2 + textH
-
-	text.Draw(screen, combatMessage, combatFont, tx, ty, color.RGBA{255, 0, 0, 255})
-}
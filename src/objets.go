@@ -0,0 +1,245 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ----------------- Objets -----------------
+// Les objets (nom, description, prix, catégorie, effets) et le stock du
+// marchand sont décrits dans data/objets.json. Le reste du jeu ne manipule
+// que leur identifiant : une faute de frappe est refusée au chargement.
+
+// ItemCategory range les objets selon leur usage
+type ItemCategory string
+
+const (
+	CategoryConsumable ItemCategory = "consumable" // Consommé à l'utilisation
+	CategoryWeapon     ItemCategory = "weapon"     // Équipé, utilisé en combat
+	CategoryArmor      ItemCategory = "armor"      // Équipé, renforce le joueur
+	CategoryUpgrade    ItemCategory = "upgrade"    // Appliqué dès qu'il est obtenu (sac...)
+)
+
+var itemCategories = map[ItemCategory]bool{
+	CategoryConsumable: true,
+	CategoryWeapon:     true,
+	CategoryArmor:      true,
+	CategoryUpgrade:    true,
+}
+
+// EffectType est le type d'effet d'un objet
+type EffectType string
+
+const (
+	EffectHeal           EffectType = "heal"             // Rend des points de vie
+	EffectAddShield      EffectType = "add_shield"       // Ajoute des points de shield
+	EffectRaiseMaxShield EffectType = "raise_max_shield" // Augmente le shield maximum
+	EffectRaiseMaxLife   EffectType = "raise_max_life"   // Augmente la vie maximum
+	EffectStrength       EffectType = "strength"         // Augmente la force
+	EffectRaiseCapacity  EffectType = "raise_capacity"   // Ajoute des cases à l'inventaire
+	EffectDefense        EffectType = "defense"          // Défense tant qu'il est équipé
+	EffectWeaponDamage   EffectType = "weapon_damage"    // Dégâts de l'arme en combat
+)
+
+var effectTypes = map[EffectType]bool{
+	EffectHeal:           true,
+	EffectAddShield:      true,
+	EffectRaiseMaxShield: true,
+	EffectRaiseMaxLife:   true,
+	EffectStrength:       true,
+	EffectRaiseCapacity:  true,
+	EffectDefense:        true,
+	EffectWeaponDamage:   true,
+}
+
+// ItemEffect est un effet d'objet
+type ItemEffect struct {
+	Type   EffectType `json:"type"`
+	Amount int        `json:"amount"`
+}
+
+// ItemDef décrit un objet
+type ItemDef struct {
+	ID          string       `json:"id"`          // Identifiant (unique, utilisé dans les sauvegardes)
+	Name        string       `json:"name"`        // Nom affiché
+	Description string       `json:"description"` // Description affichée
+	Price       int          `json:"price"`       // Prix chez le marchand
+	Category    ItemCategory `json:"category"`    // Catégorie
+	Stackable   bool         `json:"stackable"`   // Plusieurs exemplaires dans une seule case
+	MaxStack    int          `json:"max_stack"`   // Exemplaires au plus par case (objets empilables)
+	Slot        EquipSlot    `json:"slot"`        // Emplacement d'équipement (armes et armures)
+	Effects     []ItemEffect `json:"effects"`     // Effets (bonus tant qu'il est équipé)
+}
+
+// StackLimit retourne le nombre d'exemplaires que peut contenir une case
+func (d *ItemDef) StackLimit() int {
+	if !d.Stackable {
+		return 1
+	}
+	return d.MaxStack
+}
+
+// Effect retourne la valeur d'un effet de l'objet (0 s'il ne l'a pas)
+func (d *ItemDef) Effect(t EffectType) int {
+	total := 0
+	for _, e := range d.Effects {
+		if e.Type == t {
+			total += e.Amount
+		}
+	}
+	return total
+}
+
+// ItemRegistry regroupe les objets du jeu et le stock du marchand
+type ItemRegistry struct {
+	Items []ItemDef `json:"items"`
+	Shop  []string  `json:"shop"` // Objets vendus par le marchand
+
+	byID map[string]*ItemDef
+}
+
+// DefaultItems retourne les objets du jeu (data/objets.json).
+func DefaultItems() *ItemRegistry {
+	return itemsData.get()
+}
+
+// LoadItems lit et valide un fichier d'objets
+func LoadItems(path string) (*ItemRegistry, error) {
+	return loadData[ItemRegistry](path)
+}
+
+// ParseItems décode et valide un fichier d'objets
+func ParseItems(name string, data []byte) (*ItemRegistry, error) {
+	return parseData[ItemRegistry](name, data)
+}
+
+// validate vérifie chaque objet et le stock du marchand
+func (r *ItemRegistry) validate() error {
+	r.byID = map[string]*ItemDef{}
+	var errs []error
+	for i := range r.Items {
+		d := &r.Items[i]
+		if err := d.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("objet %d (%q) : %w", i+1, d.ID, err))
+			continue
+		}
+		if _, ok := r.byID[d.ID]; ok {
+			errs = append(errs, fmt.Errorf("objet %q défini deux fois", d.ID))
+			continue
+		}
+		r.byID[d.ID] = d
+	}
+	for _, id := range r.Shop {
+		if _, ok := r.byID[id]; !ok {
+			errs = append(errs, fmt.Errorf("marchand : objet inconnu %q", id))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate vérifie les valeurs d'un objet
+func (d *ItemDef) validate() error {
+	switch {
+	case d.ID == "":
+		return errors.New("identifiant manquant")
+	case strings.ContainsAny(d.ID, " \t"):
+		return errors.New("l'identifiant ne doit pas contenir d'espace")
+	case d.Name == "":
+		return errors.New("nom manquant")
+	case d.Price < 0:
+		return errors.New("prix négatif")
+	case !itemCategories[d.Category]:
+		return fmt.Errorf("catégorie inconnue %q", d.Category)
+	}
+	for _, e := range d.Effects {
+		if !effectTypes[e.Type] {
+			return fmt.Errorf("effet inconnu %q", e.Type)
+		}
+		if e.Amount <= 0 {
+			return fmt.Errorf("valeur de l'effet %q négative ou nulle", e.Type)
+		}
+	}
+	switch d.Category {
+	case CategoryWeapon:
+		if d.Slot != SlotWeapon {
+			return errors.New(`une arme doit avoir "slot": "weapon"`)
+		}
+		if d.Effect(EffectWeaponDamage) == 0 {
+			return errors.New("arme sans effet weapon_damage")
+		}
+	case CategoryArmor:
+		if _, ok := equipSlotNames[d.Slot]; !ok || d.Slot == SlotWeapon {
+			return fmt.Errorf("emplacement d'armure invalide %q", d.Slot)
+		}
+	default:
+		if d.Slot != "" {
+			return errors.New("seules les armes et armures s'équipent")
+		}
+	}
+	if d.Stackable && d.Slot != "" {
+		return errors.New("un équipement ne s'empile pas")
+	}
+	if d.Stackable && d.MaxStack < 2 {
+		return errors.New(`un objet empilable doit avoir "max_stack" d'au moins 2`)
+	}
+	if !d.Stackable && d.MaxStack != 0 {
+		return errors.New(`"max_stack" réservé aux objets empilables`)
+	}
+	return nil
+}
+
+// Item retourne l'objet portant cet identifiant
+func (r *ItemRegistry) Item(id string) (*ItemDef, bool) {
+	d, ok := r.byID[id]
+	return d, ok
+}
+
+// ShopItems retourne les objets vendus par le marchand
+func (r *ItemRegistry) ShopItems() []*ItemDef {
+	items := make([]*ItemDef, len(r.Shop))
+	for i, id := range r.Shop {
+		items[i] = r.byID[id]
+	}
+	return items
+}
+
+// ItemName retourne le nom affiché d'un objet (l'identifiant s'il est inconnu)
+func ItemName(id string) string {
+	if d, ok := DefaultItems().Item(id); ok {
+		return d.Name
+	}
+	return id
+}
+
+// ----------------- Inventaire -----------------
+
+// ItemStack est une case de l'inventaire : un objet et sa quantité
+type ItemStack struct {
+	ID    string // Identifiant de l'objet
+	Count int    // Quantité
+}
+
+// Erreurs de l'inventaire
+var (
+	ErrUnknownItem   = errors.New("objet inconnu") // Identifiant absent de data/objets.json
+	ErrInventoryFull = errors.New("inventaire plein")
+)
+
+// DefaultCapacity est le nombre de cases de l'inventaire en début de partie
+const DefaultCapacity = 10
+
+// checkInventory vérifie que tous les objets de l'inventaire existent
+// et que chaque case respecte la taille de pile de son objet
+func checkInventory(inv []ItemStack) error {
+	for _, s := range inv {
+		def, ok := DefaultItems().Item(s.ID)
+		if !ok {
+			return fmt.Errorf("%w : %q", ErrUnknownItem, s.ID)
+		}
+		if s.Count <= 0 || s.Count > def.StackLimit() {
+			return fmt.Errorf("quantité invalide pour %q : %d", s.ID, s.Count)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package source
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestItemErrors(t *testing.T) {
+	const plante = `{"id": "plante", "name": "Plante", "category": "consumable", "stackable": true, "max_stack": 5, "effects": [{"type": "heal", "amount": 50}]}`
+	tests := []struct {
+		name string
+		data string
+		want string // Morceau attendu dans le message
+	}{
+		{"doublon", `{"items": [` + plante + `, ` + plante + `]}`, `"plante" défini deux fois`},
+		{"catégorie", `{"items": [{"id": "fiole", "name": "Fiole", "category": "potion"}]}`, "catégorie inconnue"},
+		{"effet", `{"items": [{"id": "fiole", "name": "Fiole", "category": "consumable", "effects": [{"type": "fly", "amount": 1}]}]}`, `effet inconnu "fly"`},
+		{"identifiant", `{"items": [{"id": "plante curative", "name": "Plante", "category": "consumable"}]}`, "espace"},
+		{"arme", `{"items": [{"id": "baton", "name": "Bâton", "category": "weapon", "slot": "weapon"}]}`, "weapon_damage"},
+		{"marchand", `{"items": [` + plante + `], "shop": ["plante", "epe"]}`, `objet inconnu "epe"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseItems("objets.json", []byte(tt.data))
+		if err == nil {
+			t.Errorf("%s : fichier accepté", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s : %q ne cite pas %q", tt.name, err, tt.want)
+		}
+	}
+
+	r, err := ParseItems("objets.json", []byte(`{"items": [`+plante+`], "shop": ["plante"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, ok := r.Item("plante"); !ok || d.Effect(EffectHeal) != 50 || len(r.ShopItems()) != 1 {
+		t.Errorf("objet lu : %+v", d)
+	}
+}
+
+func TestUseItem(t *testing.T) {
+	p := NewWorldSeed(1).Player
+	if err := p.AjouterItem("Plante curative"); !errors.Is(err, ErrUnknownItem) {
+		t.Errorf("nom affiché accepté comme identifiant : %v", err)
+	}
+
+	p.Life, p.Shield = 10, 0
+	for _, id := range []string{"plante_curative", "potion_magique"} {
+		def, _ := DefaultItems().Item(id)
+		p.UtiliserItem(def)
+	}
+	if p.Life != 60 || p.Shield != 10 {
+		t.Errorf("vie %d, shield %d après plante et potion, attendu 60 et 10", p.Life, p.Shield)
+	}
+	if err := checkInventory([]ItemStack{{ID: "plante_curative", Count: 1}, {ID: "cactus", Count: 1}}); !errors.Is(err, ErrUnknownItem) {
+		t.Errorf("inventaire avec un objet inconnu : %v", err)
+	}
+}
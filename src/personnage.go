@@ -3,8 +3,11 @@ package source
 import (
 	"fmt"
 	"image/color"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
 )
 
 // Personnage représente le joueur
@@ -14,31 +17,151 @@ type Personnage struct {
 	Width  float64 // Largeur du sprite
 	Height float64 // Hauteur du sprite
 
-	Name      string   // Nom du joueur
-	Life      int      // Points de vie
-	MaxLife   int      // Points de vie max
-	Shield    int      // Points de bouclier
-	MaxShield int      // Bouclier max
-	Strength  int      // Force
-	Money     int      // Argent
-	Inventory []string // Inventaire
+	Name      string      // Nom du joueur
+	Life      int         // Points de vie
+	MaxLife   int         // Points de vie max
+	Shield    int         // Points de bouclier
+	MaxShield int         // Bouclier max
+	Strength  int         // Force (multiplie les dégâts des attaques)
+	Defense   int         // Défense (retirée des dégâts reçus)
+	Money     int         // Argent
+	Inventory []ItemStack // Inventaire (identifiants d'objets et quantités)
+	Capacity  int         // Nombre de cases de l'inventaire
+
+	Level      int // Niveau
+	XP         int // Expérience accumulée dans le niveau
+	StatPoints int // Points de statistique à répartir
+
+	Base      Stats                // Statistiques sans équipement (niveaux, points répartis)
+	Equipment map[EquipSlot]string // Objet porté à chaque emplacement
+
+	Statuts StatusList `json:"-"` // Effets de statut actifs (combat seulement)
+	Bonus   Modifiers  `json:"-"` // Bonus des effets actifs (rage, garde)
 }
 
-// AjouterItem ajoute un item à l’inventaire et applique ses effets
-func (p *Personnage) AjouterItem(item string) {
-	p.Inventory = append(p.Inventory, item)
-	fmt.Printf("%s a ajouté %s à son inventaire.\n", p.Name, item)
+// AjouterItem ajoute un item à l’inventaire. Les objets empilables
+// rejoignent une case du même objet qui n'est pas pleine ; sinon il faut
+// une case libre (ErrInventoryFull). Les améliorations (sac...) sont
+// appliquées tout de suite.
+func (p *Personnage) AjouterItem(id string) error {
+	def, ok := DefaultItems().Item(id)
+	if !ok {
+		return fmt.Errorf("%w : %q", ErrUnknownItem, id)
+	}
+	if def.Category == CategoryUpgrade {
+		fmt.Println(p.UtiliserItem(def))
+		return nil
+	}
+	if !p.PeutAjouter(id) {
+		return fmt.Errorf("%w : %s", ErrInventoryFull, def.Name)
+	}
+	added := false
+	for i := range p.Inventory {
+		if p.Inventory[i].ID == id && p.Inventory[i].Count < def.StackLimit() {
+			p.Inventory[i].Count++
+			added = true
+			break
+		}
+	}
+	if !added {
+		p.Inventory = append(p.Inventory, ItemStack{ID: id, Count: 1})
+	}
+	fmt.Printf("%s a ajouté %s à son inventaire.\n", p.Name, def.Name)
 	// Les effets sont appliqués uniquement lors de l'utilisation dans l'inventaire
+	return nil
+}
+
+// PeutAjouter indique s'il reste de la place pour un exemplaire de l'objet
+func (p *Personnage) PeutAjouter(id string) bool {
+	def, ok := DefaultItems().Item(id)
+	if !ok {
+		return false
+	}
+	if def.Category == CategoryUpgrade || len(p.Inventory) < p.Capacity {
+		return true
+	}
+	for _, s := range p.Inventory {
+		if s.ID == id && s.Count < def.StackLimit() {
+			return true
+		}
+	}
+	return false
+}
+
+// RetirerItem retire un exemplaire d'un item de l’inventaire
+func (p *Personnage) RetirerItem(id string) bool {
+	for i := range p.Inventory {
+		if p.Inventory[i].ID == id {
+			p.retirerCase(i)
+			return true
+		}
+	}
+	return false
+}
+
+// retirerCase retire un exemplaire de la case i de l'inventaire
+func (p *Personnage) retirerCase(i int) {
+	p.Inventory[i].Count--
+	if p.Inventory[i].Count <= 0 {
+		p.Inventory = append(p.Inventory[:i], p.Inventory[i+1:]...)
+	}
+}
+
+// Consommables retourne les cases de l'inventaire utilisables en combat
+func (p *Personnage) Consommables() []int {
+	var cases []int
+	for i, s := range p.Inventory {
+		if def, ok := DefaultItems().Item(s.ID); ok && def.Category == CategoryConsumable {
+			cases = append(cases, i)
+		}
+	}
+	return cases
+}
+
+// CompterItem retourne le nombre d'exemplaires d'un item
+func (p *Personnage) CompterItem(id string) int {
+	n := 0
+	for _, s := range p.Inventory {
+		if s.ID == id {
+			n += s.Count
+		}
+	}
+	return n
 }
 
-// RetirerItem retire un item de l’inventaire
-func (p *Personnage) RetirerItem(item string) {
-	for i, v := range p.Inventory {
-		if v == item {
-			p.Inventory = append(p.Inventory[:i], p.Inventory[i+1:]...)
-			return
+// UtiliserItem applique les effets d'un objet et décrit le résultat
+func (p *Personnage) UtiliserItem(def *ItemDef) string {
+	var results []string
+	for _, e := range def.Effects {
+		switch e.Type {
+		case EffectHeal:
+			p.Soigner(e.Amount)
+			results = append(results, fmt.Sprintf("Vie: %d/%d", p.Life, p.MaxLife))
+		case EffectAddShield:
+			p.AjouterShield(e.Amount)
+			results = append(results, fmt.Sprintf("Shield: %d/%d", p.Shield, p.MaxShield))
+		case EffectRaiseMaxShield:
+			p.Base.MaxShield += e.Amount
+			p.RecalculerStats()
+			results = append(results, fmt.Sprintf("MaxShield: %d", p.MaxShield))
+		case EffectRaiseMaxLife:
+			p.Base.MaxLife += e.Amount
+			p.RecalculerStats()
+			results = append(results, fmt.Sprintf("MaxLife: %d", p.MaxLife))
+		case EffectStrength:
+			p.Base.Strength += e.Amount
+			p.RecalculerStats()
+			results = append(results, fmt.Sprintf("Force: %d", p.Strength))
+		case EffectDefense:
+			p.Base.Defense += e.Amount
+			p.RecalculerStats()
+			results = append(results, fmt.Sprintf("Défense: %d", p.Defense))
+		case EffectRaiseCapacity:
+			p.Capacity += e.Amount
+			results = append(results, fmt.Sprintf("Cases: %d", p.Capacity))
 		}
 	}
+	return fmt.Sprintf("%s utilise %s ! %s", p.Name, def.Name, strings.Join(results, ", "))
 }
 
 // AjouterShield ajoute des points de shield
@@ -50,31 +173,38 @@ func (p *Personnage) AjouterShield(amount int) {
 	fmt.Printf("%s a gagné %d points de shield. Shield: %d/%d\n", p.Name, amount, p.Shield, p.MaxShield)
 }
 
-// PrendreDegats applique des dégâts au shield et à la vie
-func (p *Personnage) PrendreDegats(damage int) {
-	if p.Shield > 0 {
-		if damage <= p.Shield {
-			p.Shield -= damage
-			damage = 0
-		} else {
-			damage -= p.Shield
-			p.Shield = 0
-		}
-	}
+// PrendreDegats applique des dégâts fixes (sans défense ni critique) au
+// shield et à la vie
+func (p *Personnage) PrendreDegats(damage int) DamageResult {
+	res := absorbDamage(DamageResult{Target: p.Name, Raw: damage}, damage, p.Defender())
+	p.SubirDegats(res)
+	return res
+}
 
-	p.Life -= damage
-	if p.Life < 0 {
-		p.Life = 0
-	}
+// SubirDegats applique un résultat de ResolveDamage au joueur
+func (p *Personnage) SubirDegats(res DamageResult) {
+	p.Shield -= res.ShieldLost
+	p.Life -= res.Dealt
 
 	fmt.Printf("%s a pris %d points de dégâts. Vie: %d/%d, Shield: %d/%d\n",
-		p.Name, damage, p.Life, p.MaxLife, p.Shield, p.MaxShield)
+		p.Name, res.Dealt, p.Life, p.MaxLife, p.Shield, p.MaxShield)
 
 	if p.Life == 0 {
 		fmt.Printf("%s est mort!\n", p.Name)
 	}
 }
 
+// Defender retourne les défenses du joueur. En posture défensive, chaque
+// point de shield absorbe 2 dégâts.
+func (p *Personnage) Defender() Defender {
+	return Defender{Name: p.Name, Defense: p.Defense, Shield: p.Shield, Absorb: 1 + p.Bonus.Absorb, Health: p.Life}
+}
+
+// Strike retourne un coup du joueur : la force s'applique aux dégâts de base
+func (p *Personnage) Strike(name string, damage int) Strike {
+	return Strike{Attacker: p.Name, Name: name, Damage: p.Degats(damage), CritChance: playerCritChance, CritMult: critMultiplier}
+}
+
 // Soigner soigne le joueur
 func (p *Personnage) Soigner(heal int) {
 	p.Life += heal
@@ -90,8 +220,8 @@ func (p *Personnage) AfficherInventaire() {
 	if len(p.Inventory) == 0 {
 		fmt.Println("  (vide)")
 	}
-	for _, item := range p.Inventory {
-		fmt.Printf("  - %s\n", item)
+	for _, s := range p.Inventory {
+		fmt.Printf("  - %s x%d\n", ItemName(s.ID), s.Count)
 	}
 }
 
@@ -132,6 +262,15 @@ func (p *Personnage) DrawBars(screen *ebiten.Image) {
 		shieldWidth = 1
 	}
 	drawRectBar(screen, x, y+barHeight+padding, shieldWidth, barHeight, color.RGBA{0, 128, 255, 200})
+
+	// Niveau et expérience
+	xpRatio := float64(p.XP) / float64(XPToNextLevel(p.Level))
+	drawRectBar(screen, x, y-10, int(float64(barWidth)*xpRatio), 5, color.RGBA{255, 215, 0, 220})
+	level := fmt.Sprintf("Niv. %d", p.Level)
+	if p.StatPoints > 0 {
+		level += " (+)"
+	}
+	text.Draw(screen, level, basicfont.Face7x13, x-70, y+barHeight-8, color.White)
 }
 
 // drawRectBar dessine un rectangle simple (fonction renommée pour éviter conflit)
@@ -0,0 +1,101 @@
+package source
+
+import "fmt"
+
+// ----------------- Progression -----------------
+// Chaque monstre vaincu rapporte de l'expérience (champ "xp" du bestiaire).
+// Un niveau gagné augmente la vie, le shield et la force, et donne des
+// points à répartir dans l'écran des statistiques.
+
+// Gains automatiques à chaque niveau
+const (
+	levelMaxLife    = 10 // Vie max
+	levelMaxShield  = 5  // Shield max
+	levelStrength   = 1  // Force
+	levelStatPoints = 3  // Points à répartir
+)
+
+// baseStrength est la force de départ : elle correspond à 100 % des dégâts
+const baseStrength = 10
+
+// Stat est une statistique sur laquelle on peut dépenser un point
+type Stat int
+
+const (
+	StatLife     Stat = iota // +10 vie max
+	StatShield               // +10 shield max
+	StatStrength             // +1 force
+	statCount
+)
+
+var statNames = [statCount]string{
+	StatLife:     "Vie max +10",
+	StatShield:   "Shield max +10",
+	StatStrength: "Force +1",
+}
+
+// String retourne le nom de la statistique
+func (s Stat) String() string {
+	if s >= 0 && s < statCount {
+		return statNames[s]
+	}
+	return fmt.Sprintf("Stat(%d)", int(s))
+}
+
+// XPToNextLevel retourne l'expérience nécessaire pour passer au niveau suivant
+func XPToNextLevel(level int) int {
+	return 100 * level
+}
+
+// GagnerXP ajoute de l'expérience et retourne le nombre de niveaux gagnés
+func (p *Personnage) GagnerXP(xp int) int {
+	p.XP += xp
+	levels := 0
+	for p.XP >= XPToNextLevel(p.Level) {
+		p.XP -= XPToNextLevel(p.Level)
+		p.Level++
+		levels++
+
+		p.Base.MaxLife += levelMaxLife
+		p.Base.MaxShield += levelMaxShield
+		p.Base.Strength += levelStrength
+		p.RecalculerStats()
+		p.Life += levelMaxLife
+		p.StatPoints += levelStatPoints
+		fmt.Printf("%s passe au niveau %d !\n", p.Name, p.Level)
+	}
+	return levels
+}
+
+// AllouerPoint dépense un point de statistique. Retourne false s'il n'en reste plus.
+func (p *Personnage) AllouerPoint(s Stat) bool {
+	if p.StatPoints <= 0 {
+		return false
+	}
+	switch s {
+	case StatLife:
+		p.Base.MaxLife += 10
+	case StatShield:
+		p.Base.MaxShield += 10
+	case StatStrength:
+		p.Base.Strength++
+	default:
+		return false
+	}
+	p.RecalculerStats()
+	if s == StatLife {
+		p.Life += 10
+	}
+	p.StatPoints--
+	return true
+}
+
+// Degats applique la force aux dégâts d'une attaque : chaque point au-dessus
+// de la force de départ ajoute 10 % de dégâts
+func (p *Personnage) Degats(base int) int {
+	dmg := base * p.Strength / baseStrength
+	if dmg < 1 {
+		dmg = 1
+	}
+	return dmg
+}
@@ -0,0 +1,46 @@
+package source
+
+import "testing"
+
+func TestLevelUp(t *testing.T) {
+	p := NewWorldSeed(1).Player
+	p.Level, p.XP, p.StatPoints = 1, 0, 0
+	life, maxLife, maxShield, strength := p.Life, p.MaxLife, p.MaxShield, p.Strength
+
+	// 100 XP pour le niveau 2, puis 200 pour le niveau 3
+	if n := p.GagnerXP(250); n != 1 || p.Level != 2 || p.XP != 150 {
+		t.Fatalf("%d niveaux gagnés : niveau %d, %d XP", n, p.Level, p.XP)
+	}
+	if n := p.GagnerXP(350); n != 2 || p.Level != 4 || p.XP != 0 {
+		t.Fatalf("%d niveaux gagnés : niveau %d, %d XP", n, p.Level, p.XP)
+	}
+	if p.MaxLife != maxLife+3*levelMaxLife || p.Life != life+3*levelMaxLife ||
+		p.MaxShield != maxShield+3*levelMaxShield || p.Strength != strength+3*levelStrength ||
+		p.StatPoints != 3*levelStatPoints {
+		t.Errorf("niveau 4 : vie %d/%d, shield max %d, force %d, %d points", p.Life, p.MaxLife, p.MaxShield, p.Strength, p.StatPoints)
+	}
+}
+
+func TestStatPoints(t *testing.T) {
+	p := NewWorldSeed(1).Player
+	p.StatPoints = 2
+	life, maxLife, strength := p.Life, p.MaxLife, p.Strength
+
+	if !p.AllouerPoint(StatLife) || !p.AllouerPoint(StatStrength) {
+		t.Fatal("point refusé")
+	}
+	if p.AllouerPoint(StatShield) {
+		t.Error("point dépensé sans point disponible")
+	}
+	if p.MaxLife != maxLife+10 || p.Life != life+10 || p.Strength != strength+1 || p.StatPoints != 0 {
+		t.Errorf("vie %d/%d, force %d, %d points", p.Life, p.MaxLife, p.Strength, p.StatPoints)
+	}
+
+	// La force augmente les dégâts de 10 % par point
+	p.Base.Strength = baseStrength + 5
+	p.Equipment = nil
+	p.RecalculerStats()
+	if got := p.Degats(40); got != 60 {
+		t.Errorf("épée avec 15 de force : %d dégâts, attendu 60", got)
+	}
+}
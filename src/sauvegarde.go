@@ -0,0 +1,257 @@
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ----------------- Sauvegardes -----------------
+// Une sauvegarde est un fichier JSON par emplacement (slot1.json, slot2.json...)
+// dans le dossier "saves" du dossier de configuration du jeu. L'en-tête
+// "version" permet de mettre à jour les anciennes sauvegardes (migrations.go).
+
+// SaveVersion est la version actuelle du format de sauvegarde
+const SaveVersion = 12
+
+// SaveSlots est le nombre d'emplacements de sauvegarde
+const SaveSlots = 3
+
+// SaveData contient tout ce qui est nécessaire pour reprendre une partie
+type SaveData struct {
+	Version int        `json:"version"`
+	SavedAt time.Time  `json:"saved_at"`
+	World   WorldState `json:"world"`
+}
+
+// WorldState est l'état sauvegardé de la partie
+type WorldState struct {
+	Tick      int            `json:"tick"`
+	Player    Personnage     `json:"player"`
+	PlayerDir Direction      `json:"player_dir"`
+	Monsters  []Monster      `json:"monsters"` // Monstres encore présents sur la map
+	RNG       RNGState       `json:"rng"`      // Graine et état des flux aléatoires
+	Spawns    map[string]int `json:"spawns"`   // Tick de la prochaine apparition par zone
+}
+
+// SlotInfo résume un emplacement de sauvegarde pour les menus
+type SlotInfo struct {
+	Slot    int       // Numéro de l'emplacement (1 à SaveSlots)
+	Used    bool      // Une sauvegarde existe
+	SavedAt time.Time // Date de la sauvegarde
+	Life    int       // Vie du joueur
+	Money   int       // Or du joueur
+	Err     error     // Sauvegarde illisible (corrompue ou trop récente)
+}
+
+// Save capture l'état de la partie. Impossible pendant un combat.
+func (w *World) Save() (*SaveData, error) {
+	if w.Combat != nil {
+		return nil, errors.New("impossible de sauvegarder pendant un combat")
+	}
+	if w.Defeated {
+		return nil, errors.New("impossible de sauvegarder après une défaite")
+	}
+	if w.Defense != nil {
+		return nil, errors.New("impossible de sauvegarder en mode Défenseur")
+	}
+	st := WorldState{
+		Tick:      w.Tick,
+		Player:    *w.Player,
+		PlayerDir: w.PlayerDir,
+		Monsters:  make([]Monster, len(w.Monsters)),
+		RNG:       w.RNG.State(),
+		Spawns:    maps.Clone(w.Spawns),
+	}
+	st.Player.Inventory = append([]ItemStack{}, w.Player.Inventory...)
+	st.Player.Equipment = maps.Clone(w.Player.Equipment)
+	for i, m := range w.Monsters {
+		st.Monsters[i] = *m
+		st.Monsters[i].Sprites = nil
+	}
+	return &SaveData{Version: SaveVersion, SavedAt: time.Now(), World: st}, nil
+}
+
+// WorldFromSave recrée une partie à partir d'une sauvegarde
+func WorldFromSave(d *SaveData) (*World, error) {
+	if d.Version != SaveVersion {
+		return nil, fmt.Errorf("%w : version %d", ErrSaveVersion, d.Version)
+	}
+	st := d.World
+	if err := checkInventory(st.Player.Inventory); err != nil {
+		return nil, err
+	}
+	if len(st.Player.Inventory) > st.Player.Capacity {
+		return nil, fmt.Errorf("%w : %d cases pour %d places", ErrInventoryFull, len(st.Player.Inventory), st.Player.Capacity)
+	}
+	if err := checkEquipment(st.Player.Equipment); err != nil {
+		return nil, err
+	}
+	rng, err := RNGFromState(st.RNG)
+	if err != nil {
+		return nil, err
+	}
+	w := NewWorldSeed(st.RNG.Seed)
+	w.RNG = rng
+	w.Tick = st.Tick
+	player := st.Player
+	player.Inventory = append([]ItemStack{}, st.Player.Inventory...)
+	player.Equipment = maps.Clone(st.Player.Equipment)
+	if player.Equipment == nil {
+		player.Equipment = map[EquipSlot]string{}
+	}
+	player.RecalculerStats()
+	w.Player = &player
+	w.PlayerDir = st.PlayerDir
+	w.Defeated = player.Life <= 0 // Sauvegarde faite à 0 PV : reprise au camp
+	w.Spawns = maps.Clone(st.Spawns)
+	if w.Spawns == nil {
+		w.Spawns = map[string]int{}
+	}
+	w.Monsters = make([]*Monster, len(st.Monsters))
+	for i := range st.Monsters {
+		m := st.Monsters[i]
+		w.Monsters[i] = &m
+	}
+	// Pas de réouverture du marchand si la partie reprend devant lui
+	w.inShopZone = true
+	w.lastCheckpoint = w.Tick
+	return w, nil
+}
+
+// savesDir retourne le dossier des sauvegardes
+func savesDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "saves"), nil
+}
+
+// SlotPath retourne le chemin du fichier d'un emplacement
+func SlotPath(slot int) (string, error) {
+	if slot < 1 || slot > SaveSlots {
+		return "", fmt.Errorf("emplacement de sauvegarde %d invalide", slot)
+	}
+	dir, err := savesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("slot%d.json", slot)), nil
+}
+
+// WriteSave écrit une sauvegarde dans un fichier (écriture atomique)
+func WriteSave(path string, d *SaveData) error {
+	data, err := json.MarshalIndent(d, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic écrit dans un fichier temporaire puis le renomme :
+// un arrêt brutal pendant l'écriture ne laisse jamais un fichier à moitié écrit
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // Sans effet une fois renommé
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadSave lit une sauvegarde depuis un fichier et la met à jour
+// vers la version actuelle si elle est ancienne
+func ReadSave(path string) (*SaveData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d, err := DecodeSave(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s : %w", path, err)
+	}
+	return d, nil
+}
+
+// SaveSlot sauvegarde la partie dans un emplacement
+func SaveSlot(slot int, w *World) error {
+	path, err := SlotPath(slot)
+	if err != nil {
+		return err
+	}
+	d, err := w.Save()
+	if err != nil {
+		return err
+	}
+	return WriteSave(path, d)
+}
+
+// LoadSlot charge la partie d'un emplacement
+func LoadSlot(slot int) (*World, error) {
+	path, err := SlotPath(slot)
+	if err != nil {
+		return nil, err
+	}
+	d, err := ReadSave(path)
+	if err != nil {
+		return nil, err
+	}
+	return WorldFromSave(d)
+}
+
+// ListSlots décrit chaque emplacement de sauvegarde
+func ListSlots() []SlotInfo {
+	slots := make([]SlotInfo, SaveSlots)
+	for i := range slots {
+		slots[i].Slot = i + 1
+		path, err := SlotPath(i + 1)
+		if err != nil {
+			continue
+		}
+		d, err := ReadSave(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				fmt.Println("Sauvegarde illisible :", err)
+				slots[i].Err = err
+			}
+			continue
+		}
+		slots[i].Used = true
+		slots[i].SavedAt = d.SavedAt
+		slots[i].Life = d.World.Player.Life
+		slots[i].Money = d.World.Player.Money
+	}
+	return slots
+}
+
+// LatestSlot retourne l'emplacement de la sauvegarde la plus récente (0 si aucune)
+func LatestSlot() int {
+	latest := 0
+	var latestAt time.Time
+	for _, s := range ListSlots() {
+		if s.Used && (latest == 0 || s.SavedAt.After(latestAt)) {
+			latest, latestAt = s.Slot, s.SavedAt
+		}
+	}
+	return latest
+}
@@ -0,0 +1,168 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------- Sauvegardes automatiques -----------------
+// La partie est sauvegardée après chaque victoire, après chaque achat et à
+// intervalle régulier. Seules les AutosaveKeep dernières sont conservées,
+// dans le dossier "saves/auto". Un fichier de session signale un arrêt
+// brutal du jeu : au lancement suivant, on propose de reprendre la partie.
+
+// AutosaveInterval est le délai entre deux sauvegardes automatiques (2 min)
+const AutosaveInterval = 2 * 60 * TicksPerSecond
+
+// AutosaveKeep est le nombre de sauvegardes automatiques conservées
+const AutosaveKeep = 5
+
+// autosavePrefix préfixe le nom des fichiers de sauvegarde automatique
+const autosavePrefix = "auto-"
+
+// TakeCheckpoint indique si une sauvegarde automatique est due et la marque
+// comme faite. Jamais pendant un combat, quand le joueur est mort ni en
+// mode Défenseur.
+func (w *World) TakeCheckpoint() bool {
+	if w.Combat != nil || w.Player.Life <= 0 || w.Defense != nil {
+		return false
+	}
+	if !w.checkpoint && w.Tick-w.lastCheckpoint < AutosaveInterval {
+		return false
+	}
+	w.checkpoint = false
+	w.lastCheckpoint = w.Tick
+	return true
+}
+
+// autosaveDir retourne le dossier des sauvegardes automatiques
+func autosaveDir() (string, error) {
+	dir, err := savesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "auto"), nil
+}
+
+// Autosave écrit une sauvegarde automatique et supprime les plus anciennes
+func Autosave(w *World) error {
+	d, err := w.Save()
+	if err != nil {
+		return err
+	}
+	dir, err := autosaveDir()
+	if err != nil {
+		return err
+	}
+	name := autosavePrefix + d.SavedAt.UTC().Format("20060102-150405.000") + ".json"
+	if err := WriteSave(filepath.Join(dir, name), d); err != nil {
+		return err
+	}
+
+	paths, err := ListAutosaves()
+	if err != nil {
+		return err
+	}
+	for _, path := range paths[min(len(paths), AutosaveKeep):] {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAutosaves retourne les sauvegardes automatiques, de la plus récente
+// à la plus ancienne
+func ListAutosaves() ([]string, error) {
+	dir, err := autosaveDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() && strings.HasPrefix(name, autosavePrefix) && strings.HasSuffix(name, ".json") {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	// Le nom contient la date en UTC : l'ordre alphabétique est l'ordre
+	// chronologique, même après un changement d'heure ou de fuseau
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	return paths, nil
+}
+
+// LatestAutosave lit la sauvegarde automatique lisible la plus récente
+func LatestAutosave() (*SaveData, error) {
+	paths, err := ListAutosaves()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fs.ErrNotExist
+	}
+	var firstErr error
+	for _, path := range paths {
+		d, err := ReadSave(path)
+		if err == nil {
+			return d, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// ----------------- Session -----------------
+
+// sessionPath retourne le chemin du fichier présent pendant que le jeu tourne
+func sessionPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "session.lock"), nil
+}
+
+// BeginSession marque le jeu comme lancé. Retourne true si la session
+// précédente ne s'est pas terminée proprement (fichier encore présent).
+func BeginSession() bool {
+	path, err := sessionPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	crashed := err == nil
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		fmt.Println("Session non enregistrée :", err)
+		return crashed
+	}
+	if err := os.WriteFile(path, []byte(time.Now().Format(time.RFC3339)), 0o644); err != nil {
+		fmt.Println("Session non enregistrée :", err)
+	}
+	return crashed
+}
+
+// EndSession marque la fin normale du jeu
+func EndSession() {
+	path, err := sessionPath()
+	if err != nil {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fmt.Println("Fin de session :", err)
+	}
+}
@@ -0,0 +1,60 @@
+package source
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutosaveRotation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	w := NewWorldSeed(1)
+
+	for i := 0; i < AutosaveKeep+2; i++ {
+		w.Player.Money = i
+		if err := Autosave(w); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond) // Le nom du fichier est précis à la milliseconde
+	}
+	paths, err := ListAutosaves()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != AutosaveKeep {
+		t.Fatalf("%d sauvegardes automatiques gardées, attendu %d", len(paths), AutosaveKeep)
+	}
+
+	// Les plus anciennes ont été supprimées, la plus récente est relue
+	oldest, err := ReadSave(paths[len(paths)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := oldest.World.Player.Money; got != 2 {
+		t.Errorf("plus ancienne sauvegarde gardée : or %d, attendu 2", got)
+	}
+	d, err := LatestAutosave()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.World.Player.Money; got != AutosaveKeep+1 {
+		t.Errorf("dernière sauvegarde : or %d, attendu %d", got, AutosaveKeep+1)
+	}
+}
+
+func TestSessionCrash(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if BeginSession() {
+		t.Error("premier lancement vu comme un arrêt brutal")
+	}
+	// Le jeu s'arrête sans EndSession : le lancement suivant le détecte
+	if !BeginSession() {
+		t.Error("arrêt brutal non détecté")
+	}
+	EndSession()
+	if BeginSession() {
+		t.Error("arrêt normal vu comme un arrêt brutal")
+	}
+	EndSession()
+	EndSession() // Sans fichier de session : sans effet
+}
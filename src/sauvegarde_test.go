@@ -0,0 +1,150 @@
+package source
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// savedAt fixe la date des sauvegardes comparées
+var savedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// saveJSON sauvegarde la partie et retourne le JSON du fichier
+func saveJSON(t *testing.T, w *World) []byte {
+	t.Helper()
+	d, err := w.Save()
+	if err != nil {
+		t.Fatalf("Save : %v", err)
+	}
+	d.SavedAt = savedAt
+	data, err := json.MarshalIndent(d, "", "\t")
+	if err != nil {
+		t.Fatalf("encodage : %v", err)
+	}
+	return data
+}
+
+// reload relit une sauvegarde JSON comme au chargement d'un emplacement
+func reload(t *testing.T, data []byte) *World {
+	t.Helper()
+	d, err := DecodeSave(data)
+	if err != nil {
+		t.Fatalf("DecodeSave : %v", err)
+	}
+	w, err := WorldFromSave(d)
+	if err != nil {
+		t.Fatalf("WorldFromSave : %v", err)
+	}
+	return w
+}
+
+// worldState résume l'état de la partie, combat compris, pour comparer deux
+// simulations
+func worldState(t *testing.T, w *World) string {
+	t.Helper()
+	st := struct {
+		Tick     int
+		Player   *Personnage
+		Monsters []*Monster
+		RNG      RNGState
+		Spawns   map[string]int
+		Combat   *Combat
+		Defeated bool
+	}{w.Tick, w.Player, w.Monsters, w.RNG.State(), w.Spawns, w.Combat, w.Defeated}
+	data, err := json.Marshal(st)
+	if err != nil {
+		t.Fatalf("encodage : %v", err)
+	}
+	return string(data)
+}
+
+// playedWorld retourne une partie déjà avancée : objets, équipement,
+// monstres blessés et flux aléatoires entamés
+func playedWorld(t *testing.T) *World {
+	t.Helper()
+	w := NewWorldSeed(7)
+	p := w.Player
+	for _, id := range []string{"plante_curative", "plante_curative", "potion_magique", "epee_amelioree"} {
+		if err := p.AjouterItem(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Equiper("epee_amelioree"); err != nil {
+		t.Fatal(err)
+	}
+	p.Money = 321
+	p.GagnerXP(30)
+	w.Monsters[0].Health--
+	for i := 0; i < 90; i++ {
+		w.Update(Input{})
+	}
+	if w.Combat != nil {
+		t.Fatal("combat inattendu pendant la préparation")
+	}
+	return w
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	w := playedWorld(t)
+	first := saveJSON(t, w)
+	second := saveJSON(t, reload(t, first))
+	if string(first) != string(second) {
+		t.Errorf("la sauvegarde change après rechargement :\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestSaveReplay(t *testing.T) {
+	w := playedWorld(t)
+	loaded := reload(t, saveJSON(t, w))
+
+	// Mêmes entrées : la partie rechargée doit évoluer exactement pareil
+	script := []Input{{Left: true}, {Left: true, Up: true}, {}, {Down: true}, {Right: true}, {Punch: true}}
+	for i := 0; i < 600; i++ {
+		in := script[(i/20)%len(script)]
+		w.Update(in)
+		loaded.Update(in)
+	}
+	if a, b := worldState(t, w), worldState(t, loaded); a != b {
+		t.Errorf("la partie rechargée diverge :\n%s\n---\n%s", a, b)
+	}
+}
+
+func TestSaveSlotFile(t *testing.T) {
+	w := playedWorld(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "saves", "slot1.json")
+
+	d, err := w.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SavedAt = savedAt
+	if err := WriteSave(path, d); err != nil {
+		t.Fatalf("WriteSave : %v", err)
+	}
+	// Écrase le fichier : l'écriture atomique ne laisse aucun fichier temporaire
+	if err := writeFileAtomic(path, saveJSON(t, w)); err != nil {
+		t.Fatalf("writeFileAtomic : %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "slot1.json" {
+		t.Errorf("fichiers restants : %v", entries)
+	}
+
+	read, err := ReadSave(path)
+	if err != nil {
+		t.Fatalf("ReadSave : %v", err)
+	}
+	loaded, err := WorldFromSave(read)
+	if err != nil {
+		t.Fatalf("WorldFromSave : %v", err)
+	}
+	if string(saveJSON(t, loaded)) != string(saveJSON(t, w)) {
+		t.Error("l'emplacement relu ne correspond pas à la partie sauvegardée")
+	}
+}
@@ -0,0 +1,963 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// ----------------- Gestion des scènes -----------------
+// Les écrans du jeu sont empilés : seule la scène du sommet reçoit les
+// entrées. Une scène "overlay" (combat, marchand, pause...) laisse voir
+// les scènes du dessous, qui sont dessinées mais pas mises à jour.
+
+// fadeTicks est la durée du fondu lors d'un changement d'écran
+const fadeTicks = 20
+
+// Scene est un écran du jeu
+type Scene interface {
+	Enter(g *Game)                      // Appelée quand la scène arrive sur la pile
+	Exit(g *Game)                       // Appelée quand la scène quitte la pile
+	Update(g *Game) error               // Seule la scène du sommet est mise à jour
+	Draw(g *Game, screen *ebiten.Image) // Dessine la scène
+	Overlay() bool                      // true : la scène du dessous reste visible
+}
+
+// SceneManager gère la pile des scènes et les transitions
+type SceneManager struct {
+	stack []Scene
+	fade  int // Ticks restants du fondu
+}
+
+// Top retourne la scène du sommet (nil si la pile est vide)
+func (m *SceneManager) Top() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// Push ajoute une scène au sommet de la pile
+func (m *SceneManager) Push(g *Game, s Scene) {
+	m.stack = append(m.stack, s)
+	s.Enter(g)
+}
+
+// Pop retire la scène du sommet
+func (m *SceneManager) Pop(g *Game) {
+	s := m.Top()
+	if s == nil {
+		return
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	s.Exit(g)
+}
+
+// Replace vide la pile et affiche la scène avec un fondu
+func (m *SceneManager) Replace(g *Game, s Scene) {
+	for len(m.stack) > 0 {
+		m.Pop(g)
+	}
+	m.FadeIn()
+	m.Push(g, s)
+}
+
+// FadeIn lance un fondu depuis le noir
+func (m *SceneManager) FadeIn() {
+	m.fade = fadeTicks
+}
+
+// Update met à jour la scène du sommet
+func (m *SceneManager) Update(g *Game) error {
+	if m.fade > 0 {
+		m.fade--
+	}
+	if s := m.Top(); s != nil {
+		return s.Update(g)
+	}
+	return nil
+}
+
+// Draw dessine la dernière scène opaque et toutes les scènes au-dessus
+func (m *SceneManager) Draw(g *Game, screen *ebiten.Image) {
+	start := 0
+	for i := len(m.stack) - 1; i >= 0; i-- {
+		if !m.stack[i].Overlay() {
+			start = i
+			break
+		}
+	}
+	for _, s := range m.stack[start:] {
+		s.Draw(g, screen)
+	}
+
+	// Fondu
+	if m.fade > 0 {
+		w, h := screen.Size()
+		alpha := uint8(255 * m.fade / fadeTicks)
+		drawRectBar(screen, 0, 0, w, h, color.RGBA{0, 0, 0, alpha})
+	}
+}
+
+// drawShade assombrit l'écran derrière un menu
+func drawShade(screen *ebiten.Image) {
+	w, h := screen.Size()
+	drawRectBar(screen, 0, 0, w, h, color.RGBA{0, 0, 0, 140})
+}
+
+// drawCenteredText écrit un texte centré horizontalement
+func drawCenteredText(screen *ebiten.Image, str string, y int, col color.Color) {
+	face := basicfont.Face7x13
+	w, _ := screen.Size()
+	tW := text.BoundString(face, str).Dx()
+	text.Draw(screen, str, face, (w-tW)/2, y, col)
+}
+
+// ----------------- Écran titre -----------------
+
+// Boutons de l'écran titre (Start et Leave sont dessinés dans la vidéo)
+type titleButton struct {
+	label      string
+	x, y, w, h int
+}
+
+var (
+	titleStart    = titleButton{"Start", 90, 520, 120, 120}
+	titleDefender = titleButton{"Défenseur", 90, 650, 120, 40}
+	titleLeave    = titleButton{"Leave", 240, 520, 120, 120}
+	titleContinue = titleButton{"Continuer", 390, 520, 120, 120}
+)
+
+// contains indique si le point (x, y) est sur le bouton
+func (b titleButton) contains(x, y int) bool {
+	return x >= b.x && x <= b.x+b.w && y >= b.y && y <= b.y+b.h
+}
+
+// TitleScene affiche la vidéo d'introduction et les boutons Start/Défenseur/Leave/Continuer
+type TitleScene struct {
+	index         int
+	lastFrameTime time.Time
+	videoEnded    bool
+	buttons       []titleButton // Boutons disponibles
+	focus         int           // Bouton sélectionné au clavier ou à la manette
+	latestSlot    int           // Sauvegarde la plus récente (0 = aucune)
+}
+
+func (s *TitleScene) Enter(g *Game) {
+	s.index = 0
+	s.videoEnded = false
+	s.lastFrameTime = time.Now()
+	s.focus = 0
+	s.buttons = []titleButton{titleStart, titleDefender, titleLeave}
+	s.latestSlot = LatestSlot()
+	if s.latestSlot > 0 {
+		s.buttons = append(s.buttons, titleContinue)
+	}
+}
+
+func (s *TitleScene) Exit(g *Game) {}
+
+func (s *TitleScene) Overlay() bool { return false }
+
+func (s *TitleScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionClick) {
+		x, y := ebiten.CursorPosition()
+		for _, b := range s.buttons {
+			if b.contains(x, y) {
+				return s.press(g, b)
+			}
+		}
+	}
+	// Choix du bouton au clavier ou à la manette
+	dx, _ := g.navDelta()
+	s.focus = (s.focus + dx + len(s.buttons)) % len(s.buttons)
+	if g.actions.JustPressed(ActionConfirm) {
+		return s.press(g, s.buttons[s.focus])
+	}
+	if g.actions.JustPressed(ActionPause) {
+		return ebiten.Termination
+	}
+
+	// Animation vidéo menu
+	if !s.videoEnded {
+		now := time.Now()
+		if now.Sub(s.lastFrameTime) >= g.frameDelay {
+			s.index++
+			if s.index >= len(g.frames) {
+				s.index = len(g.frames) - 1
+				s.videoEnded = true
+			}
+			s.lastFrameTime = now
+		}
+	}
+	return nil
+}
+
+// press déclenche l'action d'un bouton
+func (s *TitleScene) press(g *Game, b titleButton) error {
+	switch b {
+	case titleStart:
+		g.startGame()
+	case titleDefender:
+		g.startDefender()
+	case titleContinue:
+		w, err := LoadSlot(s.latestSlot)
+		if err != nil {
+			g.scenes.Push(g, &SaveErrorScene{err: err})
+			return nil
+		}
+		g.loadWorld(w)
+	case titleLeave:
+		return ebiten.Termination
+	}
+	return nil
+}
+
+func (s *TitleScene) Draw(g *Game, screen *ebiten.Image) {
+	// Affichage du menu vidéo
+	if len(g.frames) > 0 && s.index >= 0 && s.index < len(g.frames) {
+		frame := g.frames[s.index]
+		opts := &ebiten.DrawImageOptions{}
+		opts.GeoM.Scale(
+			float64(screen.Bounds().Dx())/float64(frame.Bounds().Dx()),
+			float64(screen.Bounds().Dy())/float64(frame.Bounds().Dy()),
+		)
+		screen.DrawImage(frame, opts)
+	} else {
+		screen.Fill(color.Black)
+	}
+	if !s.videoEnded {
+		ebitenutil.DebugPrint(screen, "SAHARA DEFENDER\nVidéo en cours...")
+	} else {
+		ebitenutil.DebugPrint(screen, "SAHARA DEFENDER\nFin de la vidéo.\nClique Start, Continuer ou Leave\n(Entrée / A pour valider)")
+	}
+
+	// Boutons Défenseur et Continuer (absents de la vidéo)
+	for _, b := range s.buttons {
+		if b != titleDefender && b != titleContinue {
+			continue
+		}
+		drawRoundedRect(screen, b.x, b.y, b.w, b.h, 15, color.RGBA{210, 180, 140, 230})
+		face := basicfont.Face7x13
+		tW := text.BoundString(face, b.label).Dx()
+		text.Draw(screen, b.label, face, b.x+(b.w-tW)/2, b.y+b.h/2+4, color.RGBA{101, 67, 33, 255})
+	}
+
+	// Bouton sélectionné
+	b := s.buttons[s.focus]
+	drawOutline(screen, b.x-4, b.y-4, b.w+8, b.h+8, color.RGBA{218, 165, 32, 255})
+}
+
+// drawOutline dessine le contour d'un rectangle
+func drawOutline(screen *ebiten.Image, x, y, w, h int, col color.RGBA) {
+	drawRect(screen, x, y, w, 3, col)
+	drawRect(screen, x, y+h-3, w, 3, col)
+	drawRect(screen, x, y, 3, h, col)
+	drawRect(screen, x+w-3, y, 3, h, col)
+}
+
+// ----------------- Exploration -----------------
+
+// ExploreScene affiche la map : le joueur s'y déplace librement
+type ExploreScene struct{}
+
+func (s *ExploreScene) Enter(g *Game) {}
+
+func (s *ExploreScene) Exit(g *Game) {}
+
+func (s *ExploreScene) Overlay() bool { return false }
+
+func (s *ExploreScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionPause) {
+		g.scenes.Push(g, &PauseScene{})
+		return nil
+	}
+
+	g.updateCamera()
+	g.world.Update(g.actions.Input())
+	AnimatePlayer(g.world)
+	g.pushWorldScenes()
+	return nil
+}
+
+func (s *ExploreScene) Draw(g *Game, screen *ebiten.Image) {
+	DrawMap(screen, g.world)
+	g.world.Player.DrawBars(screen)
+	DrawMonsters(screen, g.world)
+	DrawDefense(screen, g.world)
+}
+
+// pushWorldScenes ouvre l'écran correspondant à l'état de la partie
+func (g *Game) pushWorldScenes() {
+	switch {
+	case g.world.Defense.Lost():
+		g.scenes.Push(g, &DefenseOverScene{})
+	case g.world.Defeated:
+		g.scenes.Push(g, &GameOverScene{})
+	case g.world.Combat != nil:
+		g.scenes.Push(g, &CombatScene{})
+	case g.world.ShopOpen:
+		g.scenes.Push(g, &ShopScene{})
+	case g.world.InventoryOpen:
+		g.scenes.Push(g, &InventoryScene{})
+	}
+}
+
+// ----------------- Combat -----------------
+
+// CombatScene affiche la fenêtre de combat par-dessus la map
+type CombatScene struct {
+	itemFocus int // Ligne sélectionnée dans le menu des objets
+}
+
+func (s *CombatScene) Enter(g *Game) { g.scenes.FadeIn() }
+
+func (s *CombatScene) Exit(g *Game) {}
+
+func (s *CombatScene) Overlay() bool { return true }
+
+func (s *CombatScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionPause) {
+		g.scenes.Push(g, &PauseScene{})
+		return nil
+	}
+
+	in := g.actions.Input()
+	if c := g.world.Combat; c != nil && c.ItemsOpen {
+		s.updateItems(g, &in)
+	} else {
+		s.itemFocus = 0
+		s.updateTarget(g, &in)
+	}
+
+	g.world.Update(in)
+	if g.world.Defeated {
+		g.scenes.Pop(g)
+		g.scenes.Push(g, &GameOverScene{})
+		return nil
+	}
+	if g.world.Combat == nil {
+		g.scenes.Pop(g)
+		if g.world.Victory != nil {
+			g.scenes.Push(g, &VictoryScene{})
+		}
+	}
+	return nil
+}
+
+// updateItems traduit la sélection du menu des objets en case d'inventaire
+func (s *CombatScene) updateItems(g *Game, in *Input) {
+	cases := g.world.Player.Consommables()
+	if len(cases) == 0 {
+		return
+	}
+	// Menu ouvert : A valide l'objet au lieu de frapper
+	in.Punch, in.Sword = false, false
+
+	_, dy := g.navDelta()
+	s.itemFocus = (s.itemFocus + dy + len(cases)) % len(cases)
+	if g.actions.JustPressed(ActionConfirm) {
+		in.UseItem, in.ItemSlot = true, cases[s.itemFocus]
+	}
+	if g.actions.JustPressed(ActionClick) {
+		mx, my := ebiten.CursorPosition()
+		if i := CombatItemAt(mx, my, g.screenW, g.screenH, len(cases)); i >= 0 {
+			in.UseItem, in.ItemSlot = true, cases[i]
+		}
+	}
+}
+
+// updateTarget change de cible : gauche/droite passe au monstre vivant
+// précédent/suivant, un clic vise le monstre sous le curseur
+func (s *CombatScene) updateTarget(g *Game, in *Input) {
+	c := g.world.Combat
+	if c == nil || len(c.Monsters) < 2 {
+		return
+	}
+	if dx, _ := g.navDelta(); dx != 0 {
+		in.SelectTarget, in.TargetSlot = true, c.NextTarget(dx)
+	}
+	if g.actions.JustPressed(ActionClick) {
+		mx, my := ebiten.CursorPosition()
+		if i := CombatTargetAt(mx, my, g.screenW, g.screenH, len(c.Monsters)); i >= 0 {
+			in.SelectTarget, in.TargetSlot = true, i
+		}
+	}
+}
+
+func (s *CombatScene) Draw(g *Game, screen *ebiten.Image) {
+	DrawCombatScreen(screen, g.world, currentPlayerImage(), g.controls, s.itemFocus)
+}
+
+// VictoryScene affiche le butin gagné après un combat
+type VictoryScene struct{}
+
+func (s *VictoryScene) Enter(g *Game) {}
+
+func (s *VictoryScene) Exit(g *Game) { g.world.Victory = nil }
+
+func (s *VictoryScene) Overlay() bool { return true }
+
+func (s *VictoryScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionConfirm) || g.actions.JustPressed(ActionClick) || g.actions.JustPressed(ActionPause) {
+		levelUp := g.world.Victory != nil && g.world.Victory.Levels > 0
+		g.scenes.Pop(g)
+		// Niveau gagné : répartition des points
+		if levelUp {
+			g.scenes.Push(g, &StatsScene{})
+		}
+	}
+	return nil
+}
+
+func (s *VictoryScene) Draw(g *Game, screen *ebiten.Image) {
+	v := g.world.Victory
+	if v == nil {
+		return
+	}
+	drawShade(screen)
+	_, h := screen.Size()
+	y := h/2 - 80
+	drawCenteredText(screen, "VICTOIRE !", y, color.RGBA{218, 165, 32, 255})
+	y += 30
+	drawCenteredText(screen, strings.Join(v.Monsters, ", ")+" vaincu(s)", y, color.White)
+	y += 40
+	drawCenteredText(screen, fmt.Sprintf("+ %d pièces d'or", v.Gold), y, color.RGBA{255, 215, 0, 255})
+	y += 25
+	drawCenteredText(screen, fmt.Sprintf("+ %d XP", v.XP), y, color.RGBA{120, 200, 255, 255})
+	if v.Levels > 0 {
+		y += 25
+		drawCenteredText(screen, fmt.Sprintf("NIVEAU %d ATTEINT !", g.world.Player.Level), y, color.RGBA{218, 165, 32, 255})
+	}
+	for _, id := range v.Items {
+		y += 25
+		drawCenteredText(screen, "+ "+ItemName(id), y, color.White)
+	}
+	for _, id := range v.Lost {
+		y += 25
+		drawCenteredText(screen, ItemName(id)+" perdu (inventaire)", y, color.RGBA{255, 0, 0, 255})
+	}
+	drawCenteredText(screen, "Entrée / A : continuer", y+50, color.RGBA{200, 200, 200, 255})
+}
+
+// ----------------- Marchand -----------------
+
+// ShopScene affiche le menu du marchand
+type ShopScene struct{}
+
+func (s *ShopScene) Enter(g *Game) { g.marchand.Focus = 0 }
+
+func (s *ShopScene) Exit(g *Game) {}
+
+func (s *ShopScene) Overlay() bool { return true }
+
+func (s *ShopScene) Update(g *Game) error {
+	in := Input{CloseShop: g.actions.JustPressed(ActionPause)}
+
+	// Clic ou manette : achat d'un objet
+	if g.actions.JustPressed(ActionClick) {
+		mx, my := ebiten.CursorPosition()
+		in.ShopSlot = g.marchand.SlotAt(mx, my, g.screenW, g.screenH)
+		in.BuyItem = in.ShopSlot >= 0
+	}
+	dx, dy := g.navDelta()
+	g.marchand.Navigate(dx, dy)
+	g.marchand.ScrollBy(wheelRows())
+	if g.actions.JustPressed(ActionConfirm) {
+		in.BuyItem, in.ShopSlot = true, g.marchand.Focus
+	}
+
+	g.world.Update(in)
+	if !g.world.ShopOpen {
+		g.scenes.Pop(g)
+	}
+	return nil
+}
+
+func (s *ShopScene) Draw(g *Game, screen *ebiten.Image) {
+	g.marchand.Draw(screen)
+}
+
+// ----------------- Inventaire -----------------
+
+// InventoryScene affiche l'inventaire du joueur
+type InventoryScene struct{}
+
+func (s *InventoryScene) Enter(g *Game) {
+	g.inventaire.Focus = 0
+	g.inventaire.InDoll = false
+	g.inventaire.Scroll = 0
+}
+
+// wheelRows retourne le défilement de la molette en lignes de grille
+// (vers le bas : positif)
+func wheelRows() int {
+	_, dy := ebiten.Wheel()
+	switch {
+	case dy > 0:
+		return -1
+	case dy < 0:
+		return 1
+	}
+	return 0
+}
+
+func (s *InventoryScene) Exit(g *Game) {}
+
+func (s *InventoryScene) Overlay() bool { return true }
+
+func (s *InventoryScene) Update(g *Game) error {
+	in := Input{ToggleInventory: g.actions.JustPressed(ActionToggleInventory) || g.actions.JustPressed(ActionPause)}
+
+	// Clic ou manette : utilisation d'un item ou retrait d'un équipement
+	if g.actions.JustPressed(ActionClick) {
+		mx, my := ebiten.CursorPosition()
+		in.ItemSlot = g.inventaire.SlotAt(mx, my, g.screenW, g.screenH)
+		in.UseItem = in.ItemSlot >= 0
+		in.EquipSlot, in.Unequip = g.inventaire.DollSlotAt(mx, my, g.screenW, g.screenH)
+	}
+	dx, dy := g.navDelta()
+	g.inventaire.Navigate(dx, dy)
+	g.inventaire.ScrollBy(wheelRows())
+	if g.actions.JustPressed(ActionConfirm) {
+		if g.inventaire.InDoll {
+			in.Unequip, in.EquipSlot = true, EquipSlots[g.inventaire.DollFocus]
+		} else {
+			in.UseItem, in.ItemSlot = true, g.inventaire.Focus
+		}
+	}
+
+	g.world.Update(in)
+	if !g.world.InventoryOpen {
+		g.scenes.Pop(g)
+	}
+	return nil
+}
+
+func (s *InventoryScene) Draw(g *Game, screen *ebiten.Image) {
+	g.inventaire.Draw(screen)
+}
+
+// ----------------- Pause -----------------
+
+// Choix du menu pause
+var pauseOptions = []string{"Reprendre", "Statistiques", "Sauvegarder", "Charger", "Retour au titre", "Quitter le jeu"}
+
+// PauseScene met la partie en pause et coupe la musique
+type PauseScene struct {
+	focus int
+}
+
+func (s *PauseScene) Enter(g *Game) {
+	if player != nil {
+		player.Pause()
+	}
+}
+
+func (s *PauseScene) Exit(g *Game) {
+	if player != nil {
+		player.Play()
+	}
+}
+
+func (s *PauseScene) Overlay() bool { return true }
+
+func (s *PauseScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionPause) {
+		g.scenes.Pop(g)
+		return nil
+	}
+	_, dy := g.navDelta()
+	s.focus = (s.focus + dy + len(pauseOptions)) % len(pauseOptions)
+
+	if g.actions.JustPressed(ActionConfirm) {
+		switch s.focus {
+		case 0:
+			g.scenes.Pop(g)
+		case 1:
+			g.scenes.Push(g, &StatsScene{})
+		case 2:
+			g.scenes.Push(g, &SaveMenuScene{saving: true})
+		case 3:
+			g.scenes.Push(g, &SaveMenuScene{saving: false})
+		case 4:
+			g.newWorld()
+			g.scenes.Replace(g, &TitleScene{})
+		case 5:
+			return ebiten.Termination
+		}
+	}
+	return nil
+}
+
+func (s *PauseScene) Draw(g *Game, screen *ebiten.Image) {
+	drawShade(screen)
+	_, h := screen.Size()
+	drawCenteredText(screen, "PAUSE", h/2-60, color.White)
+	drawMenuOptions(screen, pauseOptions, s.focus, h/2)
+	drawCenteredText(screen, fmt.Sprintf("Graine : %d", g.world.RNG.Seed), h/2+len(pauseOptions)*25+30, color.RGBA{200, 200, 200, 255})
+}
+
+// drawMenuOptions affiche une liste de choix centrée
+func drawMenuOptions(screen *ebiten.Image, options []string, focus, y int) {
+	for i, opt := range options {
+		col := color.RGBA{200, 200, 200, 255}
+		if i == focus {
+			opt = "> " + opt + " <"
+			col = color.RGBA{218, 165, 32, 255}
+		}
+		drawCenteredText(screen, opt, y+i*25, col)
+	}
+}
+
+// ----------------- Statistiques -----------------
+
+// StatsScene affiche le niveau du joueur et permet de répartir ses points
+type StatsScene struct {
+	focus int
+}
+
+func (s *StatsScene) Enter(g *Game) {}
+
+func (s *StatsScene) Exit(g *Game) {}
+
+func (s *StatsScene) Overlay() bool { return true }
+
+func (s *StatsScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionPause) || g.actions.JustPressed(ActionToggleInventory) {
+		g.scenes.Pop(g)
+		return nil
+	}
+	_, dy := g.navDelta()
+	s.focus = (s.focus + dy + int(statCount)) % int(statCount)
+	if g.actions.JustPressed(ActionConfirm) {
+		g.world.Player.AllouerPoint(Stat(s.focus))
+	}
+	return nil
+}
+
+func (s *StatsScene) Draw(g *Game, screen *ebiten.Image) {
+	drawShade(screen)
+	_, h := screen.Size()
+	p := g.world.Player
+	y := h/2 - 140
+	drawCenteredText(screen, fmt.Sprintf("NIVEAU %d", p.Level), y, color.RGBA{218, 165, 32, 255})
+	drawCenteredText(screen, fmt.Sprintf("XP : %d / %d", p.XP, XPToNextLevel(p.Level)), y+25, color.White)
+	drawCenteredText(screen, fmt.Sprintf("Vie max : %d   Shield max : %d   Force : %d", p.MaxLife, p.MaxShield, p.Strength), y+60, color.White)
+	drawCenteredText(screen, fmt.Sprintf("Points à répartir : %d", p.StatPoints), y+95, color.RGBA{120, 200, 255, 255})
+
+	options := make([]string, statCount)
+	for i := range options {
+		options[i] = Stat(i).String()
+	}
+	drawMenuOptions(screen, options, s.focus, h/2)
+	drawCenteredText(screen, "Entrée / A : dépenser un point   Échap : fermer", h/2+int(statCount)*25+30, color.RGBA{200, 200, 200, 255})
+}
+
+// ----------------- Sauvegardes -----------------
+
+// SaveMenuScene permet de choisir un emplacement pour sauvegarder ou charger
+type SaveMenuScene struct {
+	saving  bool // true = sauvegarder, false = charger
+	slots   []SlotInfo
+	focus   int
+	message string
+}
+
+func (s *SaveMenuScene) Enter(g *Game) { s.slots = ListSlots() }
+
+func (s *SaveMenuScene) Exit(g *Game) {}
+
+func (s *SaveMenuScene) Overlay() bool { return true }
+
+func (s *SaveMenuScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionPause) {
+		g.scenes.Pop(g)
+		return nil
+	}
+	_, dy := g.navDelta()
+	s.focus = (s.focus + dy + len(s.slots)) % len(s.slots)
+	if !g.actions.JustPressed(ActionConfirm) {
+		return nil
+	}
+
+	slot := s.slots[s.focus].Slot
+	if s.saving {
+		if err := SaveSlot(slot, g.world); err != nil {
+			s.message = err.Error()
+			return nil
+		}
+		s.slots = ListSlots()
+		s.message = fmt.Sprintf("Partie sauvegardée dans l'emplacement %d.", slot)
+		return nil
+	}
+
+	if err := s.slots[s.focus].Err; err != nil {
+		g.scenes.Push(g, &SaveErrorScene{err: err})
+		return nil
+	}
+	if !s.slots[s.focus].Used {
+		s.message = "Emplacement vide."
+		return nil
+	}
+	w, err := LoadSlot(slot)
+	if err != nil {
+		g.scenes.Push(g, &SaveErrorScene{err: err})
+		return nil
+	}
+	g.loadWorld(w)
+	return nil
+}
+
+func (s *SaveMenuScene) Draw(g *Game, screen *ebiten.Image) {
+	drawShade(screen)
+	_, h := screen.Size()
+	title := "CHARGER UNE PARTIE"
+	if s.saving {
+		title = "SAUVEGARDER LA PARTIE"
+	}
+	drawCenteredText(screen, title, h/2-60, color.White)
+
+	options := make([]string, len(s.slots))
+	for i, slot := range s.slots {
+		switch {
+		case slot.Err != nil:
+			options[i] = fmt.Sprintf("Emplacement %d - (illisible)", slot.Slot)
+		case slot.Used:
+			options[i] = fmt.Sprintf("Emplacement %d - %s - Vie %d - Or %d",
+				slot.Slot, slot.SavedAt.Format("02/01/2006 15:04"), slot.Life, slot.Money)
+		default:
+			options[i] = fmt.Sprintf("Emplacement %d - (vide)", slot.Slot)
+		}
+	}
+	drawMenuOptions(screen, options, s.focus, h/2)
+
+	if s.message != "" {
+		drawCenteredText(screen, s.message, h/2+len(options)*25+30, color.RGBA{255, 0, 0, 255})
+	}
+}
+
+// SaveErrorScene explique pourquoi une sauvegarde ne peut pas être chargée
+type SaveErrorScene struct {
+	err error
+}
+
+func (s *SaveErrorScene) Enter(g *Game) {}
+
+func (s *SaveErrorScene) Exit(g *Game) {}
+
+func (s *SaveErrorScene) Overlay() bool { return true }
+
+func (s *SaveErrorScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionConfirm) || g.actions.JustPressed(ActionPause) || g.actions.JustPressed(ActionClick) {
+		g.scenes.Pop(g)
+	}
+	return nil
+}
+
+func (s *SaveErrorScene) Draw(g *Game, screen *ebiten.Image) {
+	drawShade(screen)
+	_, h := screen.Size()
+	title, hint := "IMPOSSIBLE DE CHARGER LA SAUVEGARDE", ""
+	switch {
+	case errors.Is(s.err, ErrSaveTooNew):
+		title = "SAUVEGARDE TROP RÉCENTE"
+		hint = "Elle a été créée par une version plus récente du jeu : mettez le jeu à jour."
+	case errors.Is(s.err, ErrSaveCorrupted):
+		title = "SAUVEGARDE CORROMPUE"
+		hint = "Le fichier est endommagé. Les autres emplacements ne sont pas touchés."
+	}
+	drawCenteredText(screen, title, h/2-60, color.RGBA{255, 0, 0, 255})
+	if hint != "" {
+		drawCenteredText(screen, hint, h/2-20, color.White)
+	}
+	drawCenteredText(screen, s.err.Error(), h/2+10, color.RGBA{200, 200, 200, 255})
+	drawCenteredText(screen, "Entrée / A : retour", h/2+50, color.White)
+}
+
+// Choix de l'écran de récupération
+var recoverOptions = []string{"Reprendre la partie", "Ignorer"}
+
+// RecoverScene propose de reprendre la dernière sauvegarde automatique
+// quand le jeu ne s'est pas fermé correctement
+type RecoverScene struct {
+	save  *SaveData
+	focus int
+}
+
+func (s *RecoverScene) Enter(g *Game) {}
+
+func (s *RecoverScene) Exit(g *Game) {}
+
+func (s *RecoverScene) Overlay() bool { return true }
+
+func (s *RecoverScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionPause) {
+		g.scenes.Pop(g)
+		return nil
+	}
+	_, dy := g.navDelta()
+	s.focus = (s.focus + dy + len(recoverOptions)) % len(recoverOptions)
+	if !g.actions.JustPressed(ActionConfirm) {
+		return nil
+	}
+
+	g.scenes.Pop(g)
+	if s.focus != 0 {
+		return nil
+	}
+	w, err := WorldFromSave(s.save)
+	if err != nil {
+		g.scenes.Push(g, &SaveErrorScene{err: err})
+		return nil
+	}
+	g.loadWorld(w)
+	return nil
+}
+
+func (s *RecoverScene) Draw(g *Game, screen *ebiten.Image) {
+	drawShade(screen)
+	_, h := screen.Size()
+	p := s.save.World.Player
+	drawCenteredText(screen, "LE JEU NE S'EST PAS FERMÉ CORRECTEMENT", h/2-90, color.RGBA{218, 165, 32, 255})
+	drawCenteredText(screen, fmt.Sprintf("Dernière sauvegarde automatique : %s - Vie %d - Or %d",
+		s.save.SavedAt.Format("02/01/2006 15:04"), p.Life, p.Money), h/2-50, color.White)
+	drawMenuOptions(screen, recoverOptions, s.focus, h/2)
+}
+
+// ----------------- Défaite -----------------
+
+// Choix de l'écran de défaite
+type gameOverOption int
+
+const (
+	gameOverRespawn gameOverOption = iota // Reprendre au camp
+	gameOverReload                        // Recharger la dernière sauvegarde
+	gameOverTitle                         // Retour au titre
+)
+
+// GameOverScene s'affiche quand le joueur n'a plus de vie : reprise au
+// camp, dernière sauvegarde (manuelle ou automatique) ou retour au titre
+type GameOverScene struct {
+	options []gameOverOption
+	focus   int
+	save    *SaveData // Sauvegarde la plus récente (nil si aucune)
+}
+
+func (s *GameOverScene) Enter(g *Game) {
+	g.scenes.FadeIn()
+	s.save = latestSave()
+	s.options = []gameOverOption{gameOverRespawn}
+	if s.save != nil && g.world.Defense == nil {
+		s.options = append(s.options, gameOverReload)
+	}
+	s.options = append(s.options, gameOverTitle)
+	s.focus = 0
+}
+
+func (s *GameOverScene) Exit(g *Game) {}
+
+func (s *GameOverScene) Overlay() bool { return true }
+
+func (s *GameOverScene) Update(g *Game) error {
+	_, dy := g.navDelta()
+	s.focus = (s.focus + dy + len(s.options)) % len(s.options)
+	if !g.actions.JustPressed(ActionConfirm) {
+		return nil
+	}
+
+	switch s.options[s.focus] {
+	case gameOverRespawn:
+		g.world.Update(Input{Respawn: true})
+		g.scenes.Pop(g)
+	case gameOverReload:
+		w, err := WorldFromSave(s.save)
+		if err != nil {
+			g.scenes.Push(g, &SaveErrorScene{err: err})
+			return nil
+		}
+		g.loadWorld(w)
+	case gameOverTitle:
+		g.newWorld()
+		g.scenes.Replace(g, &TitleScene{})
+	}
+	return nil
+}
+
+// label retourne le texte d'un choix de l'écran de défaite
+func (s *GameOverScene) label(g *Game, o gameOverOption) string {
+	switch o {
+	case gameOverRespawn:
+		return fmt.Sprintf("Reprendre au camp (-%d or)", g.world.RespawnPenalty())
+	case gameOverReload:
+		return "Recharger la sauvegarde du " + s.save.SavedAt.Format("02/01/2006 15:04")
+	}
+	return "Retour au titre"
+}
+
+func (s *GameOverScene) Draw(g *Game, screen *ebiten.Image) {
+	drawShade(screen)
+	_, h := screen.Size()
+	drawCenteredText(screen, "Vous avez perdu, essayez une prochaine fois !", h/2-60, color.RGBA{255, 0, 0, 255})
+	if g.world.MessageVisible(g.world.CombatMsg) {
+		drawCenteredText(screen, g.world.CombatMsg.Text, h/2-35, color.White)
+	}
+	options := make([]string, len(s.options))
+	for i, o := range s.options {
+		options[i] = s.label(g, o)
+	}
+	drawMenuOptions(screen, options, s.focus, h/2)
+}
+
+// latestSave retourne la sauvegarde la plus récente, manuelle ou
+// automatique (nil si aucune n'est lisible)
+func latestSave() *SaveData {
+	var latest *SaveData
+	if d, err := LatestAutosave(); err == nil {
+		latest = d
+	}
+	if slot := LatestSlot(); slot > 0 {
+		path, err := SlotPath(slot)
+		if err != nil {
+			return latest
+		}
+		if d, err := ReadSave(path); err == nil && (latest == nil || d.SavedAt.After(latest.SavedAt)) {
+			latest = d
+		}
+	}
+	return latest
+}
+
+// ----------------- Fin du mode Défenseur -----------------
+
+// DefenseOverScene s'affiche quand le camp est tombé : score et retour au titre
+type DefenseOverScene struct{}
+
+func (s *DefenseOverScene) Enter(g *Game) { g.scenes.FadeIn() }
+
+func (s *DefenseOverScene) Exit(g *Game) {}
+
+func (s *DefenseOverScene) Overlay() bool { return true }
+
+func (s *DefenseOverScene) Update(g *Game) error {
+	if g.actions.JustPressed(ActionConfirm) || g.actions.JustPressed(ActionClick) {
+		g.newWorld()
+		g.scenes.Replace(g, &TitleScene{})
+	}
+	return nil
+}
+
+func (s *DefenseOverScene) Draw(g *Game, screen *ebiten.Image) {
+	drawShade(screen)
+	_, h := screen.Size()
+	drawCenteredText(screen, "LE CAMP EST TOMBÉ !", h/2-40, color.RGBA{255, 0, 0, 255})
+	drawCenteredText(screen, fmt.Sprintf("Score : %d vague(s) repoussée(s)", g.world.Defense.Survived), h/2, color.RGBA{218, 165, 32, 255})
+	drawCenteredText(screen, "Entrée / A : retour au titre", h/2+30, color.White)
+}
@@ -0,0 +1,79 @@
+package source
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// fakeScene note les appels reçus dans un journal partagé
+type fakeScene struct {
+	name string
+	log  *[]string
+}
+
+func (s *fakeScene) Enter(g *Game)                      { *s.log = append(*s.log, "enter "+s.name) }
+func (s *fakeScene) Exit(g *Game)                       { *s.log = append(*s.log, "exit "+s.name) }
+func (s *fakeScene) Update(g *Game) error               { *s.log = append(*s.log, "update "+s.name); return nil }
+func (s *fakeScene) Draw(g *Game, screen *ebiten.Image) {}
+func (s *fakeScene) Overlay() bool                      { return false }
+
+func TestSceneStack(t *testing.T) {
+	var log []string
+	var m SceneManager
+	title := &fakeScene{"titre", &log}
+	explore := &fakeScene{"exploration", &log}
+	pause := &fakeScene{"pause", &log}
+
+	m.Push(nil, title)
+	m.Replace(nil, explore)
+	m.Push(nil, pause)
+	if m.Top() != pause {
+		t.Fatalf("sommet %v, attendu la pause", m.Top())
+	}
+	if err := m.Update(nil); err != nil {
+		t.Fatal(err)
+	}
+	m.Pop(nil)
+	if err := m.Update(nil); err != nil {
+		t.Fatal(err)
+	}
+	m.Pop(nil)
+	m.Pop(nil) // Pile vide : sans effet
+	if m.Top() != nil {
+		t.Errorf("sommet %v après avoir vidé la pile", m.Top())
+	}
+
+	want := []string{
+		"enter titre",
+		"exit titre", "enter exploration", // Replace vide la pile
+		"enter pause",
+		"update pause", // Seul le sommet est mis à jour
+		"exit pause",
+		"update exploration",
+		"exit exploration",
+	}
+	if !slices.Equal(log, want) {
+		t.Errorf("appels %q, attendu %q", log, want)
+	}
+}
+
+func TestSceneFade(t *testing.T) {
+	var log []string
+	var m SceneManager
+	m.Push(nil, &fakeScene{"titre", &log})
+	if m.fade != 0 {
+		t.Errorf("fondu %d sur un simple ajout", m.fade)
+	}
+	m.Replace(nil, &fakeScene{"exploration", &log})
+	for i := 0; i < fadeTicks; i++ {
+		if m.fade != fadeTicks-i {
+			t.Fatalf("tick %d : fondu %d", i, m.fade)
+		}
+		m.Update(nil)
+	}
+	if m.fade != 0 {
+		t.Errorf("fondu %d à la fin", m.fade)
+	}
+}
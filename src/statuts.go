@@ -0,0 +1,247 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math/rand/v2"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// ----------------- Effets de statut -----------------
+// Poison, brûlure, étourdissement... touchent tous les combattants
+// pendant un nombre de tours. Chaque effet a une règle de cumul et des
+// fonctions appelées à l'application, à chaque tour et à l'expiration.
+// Les effets qui changent les statistiques (rage, garde) ajoutent un bonus
+// à l'application et le retirent à l'expiration.
+// Les effets ne durent qu'un combat.
+
+// StatusType est le type d'un effet de statut
+type StatusType string
+
+const (
+	StatusPoison StatusType = "poison" // Dégâts à chaque tour, l'intensité se cumule
+	StatusBurn   StatusType = "burn"   // Dégâts à chaque tour, renouvelée si réappliquée
+	StatusBleed  StatusType = "bleed"  // Dégâts à chaque tour, la durée se cumule
+	StatusStun   StatusType = "stun"   // Passe son tour
+	StatusBlind  StatusType = "blind"  // Rate ses attaques
+	StatusRage   StatusType = "rage"   // Force (ou dégâts du monstre) augmentée
+	StatusGuard  StatusType = "guard"  // Posture défensive : le shield absorbe le double pendant N tours
+)
+
+// StackRule dit ce qui se passe quand un effet déjà actif est réappliqué
+type StackRule int
+
+const (
+	StackRefresh   StackRule = iota // Durée et puissance renouvelées (la plus grande gardée)
+	StackIntensity                  // Puissances additionnées, durée renouvelée
+	StackDuration                   // Durées additionnées
+	StackIgnore                     // Sans effet tant que l'effet est actif
+)
+
+// Status est un effet actif
+type Status struct {
+	Type  StatusType
+	Turns int // Tours restants
+	Power int // Dégâts par tour, bonus de force...
+}
+
+// statusKind décrit le comportement d'un type d'effet
+type statusKind struct {
+	Name     string                             // Nom affiché
+	Icon     string                             // Lettre de l'icône
+	Color    color.RGBA                         // Couleur de l'icône
+	Stack    StackRule                          // Règle de cumul
+	OnApply  func(t Combatant, s Status) string // Appelée quand l'effet commence
+	Tick     func(t Combatant, s Status) string // Appelée à chaque tour
+	OnExpire func(t Combatant, s Status) string // Appelée quand l'effet se termine
+}
+
+// tickDamage inflige les dégâts par tour d'un effet
+func tickDamage(verb string) func(t Combatant, s Status) string {
+	return func(t Combatant, s Status) string {
+		t.degatsStatut(s.Power)
+		return fmt.Sprintf("%s %s (-%d)", t.CombatName(), verb, s.Power)
+	}
+}
+
+var statusKinds = map[StatusType]statusKind{
+	StatusPoison: {Name: "Poison", Icon: "P", Color: color.RGBA{110, 170, 40, 255}, Stack: StackIntensity,
+		Tick: tickDamage("souffre du poison")},
+	StatusBurn: {Name: "Brûlure", Icon: "B", Color: color.RGBA{230, 100, 20, 255}, Stack: StackRefresh,
+		Tick: tickDamage("brûle")},
+	StatusBleed: {Name: "Saignement", Icon: "S", Color: color.RGBA{170, 20, 20, 255}, Stack: StackDuration,
+		Tick: tickDamage("saigne")},
+	StatusStun:  {Name: "Étourdi", Icon: "E", Color: color.RGBA{200, 180, 40, 255}, Stack: StackIgnore},
+	StatusBlind: {Name: "Aveuglé", Icon: "A", Color: color.RGBA{90, 90, 90, 255}, Stack: StackRefresh},
+	StatusRage: {Name: "Rage", Icon: "R", Color: color.RGBA{200, 40, 120, 255}, Stack: StackRefresh,
+		OnApply: func(t Combatant, s Status) string {
+			t.modifierStats(Modifiers{Strength: s.Power})
+			return fmt.Sprintf("force +%d", s.Power)
+		},
+		OnExpire: func(t Combatant, s Status) string {
+			t.modifierStats(Modifiers{Strength: -s.Power})
+			return "force normale"
+		}},
+	StatusGuard: {Name: "Garde", Icon: "G", Color: color.RGBA{0, 128, 255, 255}, Stack: StackRefresh,
+		OnApply: func(t Combatant, s Status) string {
+			t.modifierStats(Modifiers{Absorb: 1})
+			return "chaque point de shield absorbe 2 dégâts"
+		},
+		OnExpire: func(t Combatant, s Status) string {
+			t.modifierStats(Modifiers{Absorb: -1})
+			return ""
+		}},
+}
+
+// String retourne le nom affiché de l'effet
+func (t StatusType) String() string {
+	if k, ok := statusKinds[t]; ok {
+		return k.Name
+	}
+	return string(t)
+}
+
+// StatusList regroupe les effets actifs d'un combattant
+type StatusList []Status
+
+// Has indique si l'effet est actif
+func (l StatusList) Has(t StatusType) bool {
+	for _, s := range l {
+		if s.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// AppliquerStatut ajoute un effet en suivant sa règle de cumul
+func AppliquerStatut(t Combatant, s Status) string {
+	kind := statusKinds[s.Type]
+	l := t.statuts()
+	for i := range *l {
+		cur := &(*l)[i]
+		if cur.Type != s.Type {
+			continue
+		}
+		detail := ""
+		switch kind.Stack {
+		case StackIgnore:
+			return ""
+		case StackDuration:
+			cur.Turns += s.Turns
+		case StackIntensity:
+			cur.Power += s.Power
+			cur.Turns = max(cur.Turns, s.Turns)
+		default:
+			// Rage : l'ancien bonus est retiré avant d'appliquer le nouveau
+			if kind.OnExpire != nil {
+				kind.OnExpire(t, *cur)
+			}
+			cur.Turns = max(cur.Turns, s.Turns)
+			cur.Power = max(cur.Power, s.Power)
+			if kind.OnApply != nil {
+				detail = kind.OnApply(t, *cur)
+			}
+		}
+		return withDetail(fmt.Sprintf("%s : %s (%d tours)", t.CombatName(), kind.Name, cur.Turns), detail)
+	}
+	*l = append(*l, s)
+	detail := ""
+	if kind.OnApply != nil {
+		detail = kind.OnApply(t, s)
+	}
+	return withDetail(fmt.Sprintf("%s : %s (%d tours)", t.CombatName(), kind.Name, s.Turns), detail)
+}
+
+// TickStatuts fait passer un tour : effets par tour, puis expiration
+func TickStatuts(t Combatant) []string {
+	var msgs []string
+	l := t.statuts()
+	kept := (*l)[:0]
+	for _, s := range *l {
+		kind := statusKinds[s.Type]
+		if kind.Tick != nil {
+			msgs = append(msgs, kind.Tick(t, s))
+		}
+		s.Turns--
+		if s.Turns > 0 {
+			kept = append(kept, s)
+			continue
+		}
+		detail := ""
+		if kind.OnExpire != nil {
+			detail = kind.OnExpire(t, s)
+		}
+		msgs = append(msgs, withDetail(fmt.Sprintf("%s : fin de %s", t.CombatName(), kind.Name), detail))
+	}
+	*l = kept
+	return msgs
+}
+
+// RetirerStatuts termine tous les effets (fin du combat)
+func RetirerStatuts(t Combatant) {
+	l := t.statuts()
+	for _, s := range *l {
+		if kind := statusKinds[s.Type]; kind.OnExpire != nil {
+			kind.OnExpire(t, s)
+		}
+	}
+	*l = nil
+}
+
+// withDetail complète le message d'un effet par celui de sa fonction
+// d'application ou d'expiration
+func withDetail(msg, detail string) string {
+	if detail == "" {
+		return msg
+	}
+	return msg + ", " + detail
+}
+
+// ----------------- Effets infligés par les monstres -----------------
+
+// StatusChance est un effet qu'un monstre peut infliger en touchant
+type StatusChance struct {
+	Status StatusType `json:"status"` // Effet infligé
+	Turns  int        `json:"turns"`  // Durée en tours
+	Power  int        `json:"power"`  // Puissance (dégâts par tour...)
+	Chance float64    `json:"chance"` // Probabilité entre 0 et 1
+}
+
+// validate vérifie un effet de monstre
+func (s *StatusChance) validate() error {
+	switch {
+	case statusKinds[s.Status].Name == "":
+		return fmt.Errorf("effet inconnu %q", s.Status)
+	case s.Turns <= 0:
+		return errors.New("durée négative ou nulle")
+	case s.Power < 0:
+		return errors.New("puissance négative")
+	case s.Chance <= 0 || s.Chance > 1:
+		return fmt.Errorf("chance invalide : %v", s.Chance)
+	}
+	return nil
+}
+
+// Roll tire l'effet au sort. Retourne false s'il n'est pas infligé.
+func (s *StatusChance) Roll(r *rand.Rand) (Status, bool) {
+	if r.Float64() >= s.Chance {
+		return Status{}, false
+	}
+	return Status{Type: s.Status, Turns: s.Turns, Power: s.Power}, true
+}
+
+// ----------------- Affichage -----------------
+
+// drawStatusIcons dessine une icône par effet actif : sa lettre et ses tours restants
+func drawStatusIcons(screen *ebiten.Image, l StatusList, x, y int) {
+	for i, s := range l {
+		kind := statusKinds[s.Type]
+		iconX := x + i*38
+		drawRoundedRect(screen, iconX, y, 34, 20, 6, kind.Color)
+		text.Draw(screen, fmt.Sprintf("%s%d", kind.Icon, s.Turns), combatFonts, iconX+6, y+15, color.White)
+	}
+}
@@ -0,0 +1,109 @@
+package source
+
+import "testing"
+
+func TestRageStrength(t *testing.T) {
+	p := NewWorldSeed(1).Player
+	base := p.Strength
+	const amulet = 2 // Force de l'amulette
+
+	AppliquerStatut(p, Status{Type: StatusRage, Turns: 3, Power: rageStrength})
+	AppliquerStatut(p, Status{Type: StatusRage, Turns: 3, Power: rageStrength}) // Renouvelée, pas cumulée
+	if p.Strength != base+rageStrength {
+		t.Fatalf("force %d en rage, attendu %d", p.Strength, base+rageStrength)
+	}
+
+	// Changer d'équipement pendant la rage la garde une seule fois
+	if err := p.AjouterItem("amulette"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Equiper("amulette"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Strength != base+amulet+rageStrength {
+		t.Errorf("force %d après l'équipement, attendu %d", p.Strength, base+amulet+rageStrength)
+	}
+	if err := p.Desequiper(SlotAccessory); err != nil {
+		t.Fatal(err)
+	}
+	if p.Strength != base+rageStrength {
+		t.Errorf("force %d après le retrait, attendu %d", p.Strength, base+rageStrength)
+	}
+
+	for i := 0; i < 3; i++ {
+		TickStatuts(p)
+	}
+	if p.Strength != base || p.Statuts.Has(StatusRage) {
+		t.Errorf("force %d à la fin de la rage, attendu %d", p.Strength, base)
+	}
+
+	AppliquerStatut(p, Status{Type: StatusRage, Turns: 3, Power: rageStrength})
+	RetirerStatuts(p)
+	if p.Strength != base {
+		t.Errorf("force %d à la fin du combat, attendu %d", p.Strength, base)
+	}
+}
+
+func TestRageMonster(t *testing.T) {
+	m := NewWorldSeed(1).Monsters[0]
+	damage := m.Damage
+	AppliquerStatut(m, Status{Type: StatusRage, Turns: 1, Power: 4})
+	if got := m.Strike().Damage; got != damage+4 || m.Damage != damage {
+		t.Errorf("coup de %d en rage (dégâts de base %d), attendu %d", got, m.Damage, damage+4)
+	}
+	TickStatuts(m)
+	if got := m.Strike().Damage; got != damage {
+		t.Errorf("coup de %d après la rage, attendu %d", got, damage)
+	}
+}
+
+func TestGuardTurns(t *testing.T) {
+	p := NewWorldSeed(1).Player
+	AppliquerStatut(p, Status{Type: StatusGuard, Turns: 3})
+	// La garde dure 3 tours, quel que soit le nombre d'attaques reçues
+	for turn := 1; turn <= 4; turn++ {
+		want := 2
+		if turn > 3 {
+			want = 1
+		}
+		if got := p.Defender().Absorb; got != want {
+			t.Errorf("tour %d : le shield absorbe %d par point, attendu %d", turn, got, want)
+		}
+		TickStatuts(p)
+	}
+}
+
+func TestStatusHooks(t *testing.T) {
+	p := NewWorldSeed(1).Player
+	base := p.Strength
+
+	msg := AppliquerStatut(p, Status{Type: StatusRage, Turns: 1, Power: 4})
+	if want := p.Name + " : Rage (1 tours), force +4"; msg != want {
+		t.Errorf("application : %q, attendu %q", msg, want)
+	}
+	AppliquerStatut(p, Status{Type: StatusGuard, Turns: 2})
+	if p.Strength != base+4 || p.Defender().Absorb != 2 {
+		t.Fatalf("bonus appliqués : force %d, absorption %d", p.Strength, p.Defender().Absorb)
+	}
+
+	// La rage expire au premier tour, la garde au second
+	msgs := TickStatuts(p)
+	if len(msgs) != 1 || msgs[0] != p.Name+" : fin de Rage, force normale" {
+		t.Errorf("expiration : %q", msgs)
+	}
+	if p.Strength != base || p.Defender().Absorb != 2 {
+		t.Errorf("après la rage : force %d, absorption %d", p.Strength, p.Defender().Absorb)
+	}
+	TickStatuts(p)
+	if p.Bonus != (Modifiers{}) || p.Defender().Absorb != 1 {
+		t.Errorf("bonus restants : %+v", p.Bonus)
+	}
+
+	// Fin du combat : tous les bonus sont retirés
+	AppliquerStatut(p, Status{Type: StatusRage, Turns: 3, Power: 4})
+	AppliquerStatut(p, Status{Type: StatusGuard, Turns: 3})
+	RetirerStatuts(p)
+	if p.Bonus != (Modifiers{}) || p.Strength != base || p.Defender().Absorb != 1 {
+		t.Errorf("fin du combat : bonus %+v, force %d", p.Bonus, p.Strength)
+	}
+}
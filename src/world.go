@@ -0,0 +1,212 @@
+package source
+
+import "math/rand/v2"
+
+// ----------------- Simulation du jeu -----------------
+// Le World contient toutes les règles du jeu (déplacement, combat, marchand,
+// inventaire). Il avance d'un tick à partir d'un instantané des entrées et
+// n'appelle jamais Ebiten : il peut donc tourner sans fenêtre (tests, CI).
+
+// TicksPerSecond correspond au nombre de ticks de simulation par seconde
+const TicksPerSecond = 60
+
+// MessageDuration est la durée d'affichage d'un message temporaire (2 s)
+const MessageDuration = 2 * TicksPerSecond
+
+// Direction du regard du joueur
+type Direction int
+
+const (
+	DirDown Direction = iota
+	DirUp
+	DirLeft
+	DirRight
+)
+
+// Input est l'instantané des commandes du joueur pour un tick.
+// Les actions (Punch, Sword...) valent true uniquement sur le tick de l'appui.
+type Input struct {
+	Up, Down, Left, Right bool // Déplacement (touches maintenues)
+
+	Punch        bool // Coup de poing
+	Sword        bool // Attaque à l'épée
+	CombatItems  bool // Ouvrir/fermer le menu des objets en combat
+	UseSkill     bool // Utiliser la compétence SkillSlot
+	SkillSlot    int  // Compétence visée (ordre des compétences débloquées)
+	Flee         bool // Fuir le combat
+	SelectTarget bool // Viser le monstre TargetSlot en combat
+	TargetSlot   int  // Monstre visé (ordre du combat)
+	Respawn      bool // Reprendre au camp après une défaite
+
+	ToggleInventory bool      // Ouvrir/fermer l'inventaire
+	UseItem         bool      // Utiliser (ou équiper) l'item ItemSlot de l'inventaire (aussi en combat)
+	ItemSlot        int       // Case de l'inventaire visée
+	Unequip         bool      // Retirer l'équipement porté en EquipSlot
+	EquipSlot       EquipSlot // Emplacement d'équipement visé
+	BuyItem         bool      // Acheter l'objet ShopSlot du marchand
+	ShopSlot        int       // Case du marchand visée
+	CloseShop       bool      // Quitter le marchand
+}
+
+// Message est un message temporaire daté en ticks
+type Message struct {
+	Text string
+	Tick int
+}
+
+// World représente l'état complet de la partie
+type World struct {
+	Tick int // Nombre de ticks écoulés
+
+	Player       *Personnage // Joueur
+	PlayerSpeed  float64     // Vitesse de déplacement du joueur
+	PlayerDir    Direction   // Direction du regard
+	PlayerMoving bool        // Le joueur s'est déplacé ce tick
+
+	Monsters  []*Monster     // Monstres présents sur la map
+	Combat    *Combat        // Combat en cours (nil hors combat)
+	SafeUntil int            // Tick de fin de l'immunité aux combats (après une fuite)
+	Victory   *Victory       // Butin de la dernière victoire, à afficher (nil sinon)
+	Defeated  bool           // Le joueur est mort : rien ne bouge avant la reprise
+	Spawns    map[string]int // Tick de la prochaine apparition des zones dépeuplées
+	Defense   *Defense       // Mode Défenseur (nil en partie normale)
+
+	Shop          *Marchand // Stand du marchand
+	ShopOpen      bool      // Menu du marchand ouvert
+	InventoryOpen bool      // Inventaire ouvert ou fermé
+	inShopZone    bool      // Le joueur était dans la zone du marchand au tick précédent
+
+	checkpoint     bool // Sauvegarde automatique demandée (victoire, achat)
+	lastCheckpoint int  // Tick de la dernière sauvegarde automatique
+
+	CombatMsg    Message // Dernier message de combat
+	ShopMsg      Message // Dernier message du marchand
+	InventoryMsg Message // Dernier message de l'inventaire
+
+	RNG *RNG // Hasard de la partie (un flux par usage)
+}
+
+// NewPlayer crée le héros avec ses statistiques de départ
+func NewPlayer() *Personnage {
+	p := &Personnage{
+		PosX:      campX,
+		PosY:      campY,
+		Width:     64,
+		Height:    64,
+		Name:      "Héros",
+		Life:      100,
+		Shield:    0,
+		Money:     100,
+		Inventory: []ItemStack{},
+		Capacity:  DefaultCapacity,
+		Level:     1,
+		Base: Stats{
+			MaxLife:   100,
+			MaxShield: 100, // valeur de base
+			Strength:  baseStrength,
+		},
+		Equipment: map[EquipSlot]string{},
+	}
+	p.RecalculerStats()
+	return p
+}
+
+// NewWorld crée une nouvelle partie avec le joueur, les monstres et le marchand
+func NewWorld() *World {
+	return NewWorldSeed(rand.Uint64())
+}
+
+// NewWorldSeed crée une nouvelle partie dont le hasard dépend de seed :
+// la même graine et les mêmes entrées donnent la même partie
+func NewWorldSeed(seed uint64) *World {
+	w := &World{
+		Player:      NewPlayer(),
+		PlayerSpeed: 3,
+		Monsters:    InitMonsters(),
+		Spawns:      map[string]int{},
+		Shop:        NewMarchand(),
+		RNG:         NewRNG(seed),
+	}
+	w.fillZones()
+	return w
+}
+
+// Update avance la simulation d'un tick.
+// Un seul écran reçoit les entrées : défaite, combat, marchand, inventaire ou exploration.
+func (w *World) Update(in Input) {
+	w.Tick++
+
+	switch {
+	case w.Defense.Lost():
+		// Le camp est tombé : la partie est finie
+	case w.Defeated:
+		w.updateDefeat(in)
+	case w.Combat != nil:
+		w.updateCombat(in)
+	case w.ShopOpen:
+		w.updateShop(in)
+	case w.InventoryOpen:
+		w.updateInventory(in)
+	default:
+		w.updateExplore(in)
+	}
+}
+
+// updateExplore déplace le joueur et les monstres sur la map
+func (w *World) updateExplore(in Input) {
+	w.updatePlayer(in)
+	w.updateMonsters()
+	if w.Defense != nil {
+		w.updateDefense()
+	} else {
+		w.updateSpawns()
+	}
+	w.checkCollisionWithPlayerCombat()
+	if w.Combat != nil {
+		return
+	}
+	w.checkShopZone()
+	if in.ToggleInventory && !w.ShopOpen {
+		w.InventoryOpen = true
+	}
+}
+
+// MessageVisible indique si un message temporaire doit encore être affiché
+func (w *World) MessageVisible(m Message) bool {
+	return m.Text != "" && w.Tick-m.Tick < MessageDuration
+}
+
+// say crée un message daté du tick courant
+func (w *World) say(text string) Message {
+	return Message{Text: text, Tick: w.Tick}
+}
+
+// Déplace le joueur selon les entrées
+func (w *World) updatePlayer(in Input) {
+	p := w.Player
+	w.PlayerMoving = false
+
+	if in.Up {
+		p.PosY -= w.PlayerSpeed
+		w.PlayerDir = DirUp
+		w.PlayerMoving = true
+	} else if in.Down {
+		p.PosY += w.PlayerSpeed
+		w.PlayerDir = DirDown
+		w.PlayerMoving = true
+	}
+	if in.Left {
+		p.PosX -= w.PlayerSpeed
+		w.PlayerDir = DirLeft
+		w.PlayerMoving = true
+	} else if in.Right {
+		p.PosX += w.PlayerSpeed
+		w.PlayerDir = DirRight
+		w.PlayerMoving = true
+	}
+}
+
+// overlaps teste l'intersection de deux rectangles
+func overlaps(x1, y1, w1, h1, x2, y2, w2, h2 float64) bool {
+	return x1 < x2+w2 && x1+w1 > x2 && y1 < y2+h2 && y1+h1 > y2
+}
@@ -0,0 +1,89 @@
+package source
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// chase retourne les entrées qui rapprochent le joueur du monstre
+func chase(w *World, m *Monster) Input {
+	p := w.Player
+	mx, my := m.center()
+	dx, dy := mx-(p.PosX+p.Width/2), my-(p.PosY+p.Height/2)
+	return Input{Up: dy < -1, Down: dy > 1, Left: dx < -1, Right: dx > 1}
+}
+
+// playSession joue une session sans fenêtre : le joueur marche vers le
+// scorpion, combat à l'épée jusqu'à la victoire puis ferme l'écran de butin
+func playSession(t *testing.T, seed uint64) *World {
+	t.Helper()
+	w := NewWorldSeed(seed)
+	p := w.Player
+	if err := p.AjouterItem("epee_amelioree"); err != nil {
+		t.Fatal(err)
+	}
+	target := slices.IndexFunc(w.Monsters, func(m *Monster) bool { return m.Name == "Scorpion" })
+	if target < 0 {
+		t.Fatal("pas de scorpion sur la map")
+	}
+	scorpion := w.Monsters[target]
+
+	// Exploration : inventaire ouvert pour équiper l'épée, puis marche
+	w.Update(Input{ToggleInventory: true})
+	w.Update(Input{UseItem: true, ItemSlot: 0})
+	w.Update(Input{ToggleInventory: true})
+	if !p.Porte("epee_amelioree") {
+		t.Fatal("épée non équipée")
+	}
+	for i := 0; w.Combat == nil; i++ {
+		if i > 60*TicksPerSecond {
+			t.Fatal("aucune rencontre après une minute de marche")
+		}
+		w.Update(chase(w, scorpion))
+	}
+
+	// Combat : l'épée à chaque tour du joueur
+	for i := 0; w.Combat != nil; i++ {
+		if i > 1000 || w.Defeated {
+			t.Fatal("le combat ne se termine pas par une victoire")
+		}
+		w.Update(Input{Sword: w.Combat.PlayerTurn})
+	}
+	return w
+}
+
+func TestWorldSession(t *testing.T) {
+	w := playSession(t, 11)
+	if w.Defeated || w.Victory == nil {
+		t.Fatalf("victoire attendue (défaite : %v)", w.Defeated)
+	}
+	if !slices.Contains(w.Victory.Monsters, "Scorpion") {
+		t.Errorf("monstres vaincus : %v", w.Victory.Monsters)
+	}
+	for _, m := range w.Monsters {
+		if !m.Vivant() {
+			t.Errorf("%s vaincu encore sur la map", m.Name)
+		}
+	}
+	if w.Player.Money != 100+w.Victory.Gold || w.Victory.XP == 0 {
+		t.Errorf("butin non donné : %d pièces, %+v", w.Player.Money, w.Victory)
+	}
+
+	// La partie reprend : l'exploration répond de nouveau aux entrées
+	x := w.Player.PosX
+	w.Update(Input{Right: true})
+	if w.Player.PosX <= x {
+		t.Error("le joueur ne bouge plus après le combat")
+	}
+}
+
+func TestWorldSessionSeed(t *testing.T) {
+	a, b := playSession(t, 5), playSession(t, 5)
+	if sa, sb := worldState(t, a), worldState(t, b); sa != sb {
+		t.Errorf("même graine, parties différentes :\n%s\n---\n%s", sa, sb)
+	}
+	if !reflect.DeepEqual(a.Victory, b.Victory) {
+		t.Errorf("butins différents : %+v et %+v", a.Victory, b.Victory)
+	}
+}